@@ -1,10 +1,14 @@
 package main
 
 import (
-	"github.com/ciroque/advent-of-code-2020/support"
+	"advent-of-code-2021/utility/collections"
+	"bufio"
+	"fmt"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -17,8 +21,6 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
-	_ = loadPuzzleInput()
-
 	waitCount := 3
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -50,11 +52,10 @@ func doExamples(waitGroup *sync.WaitGroup) {
 }
 
 func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
+	WindowSize := 1
 	start := time.Now()
 
-	depthMeasurements := loadPuzzleInput()
-
-	depthMeasurementIncreaseCount := countDepthIncreases(depthMeasurements)
+	depthMeasurementIncreaseCount := countDepthIncreases("puzzle-input.dat", WindowSize)
 
 	channel <- Result{
 		answer:   depthMeasurementIncreaseCount,
@@ -67,13 +68,7 @@ func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
 	WindowSize := 3
 	start := time.Now()
 
-	depthMeasurements := loadPuzzleInput()
-
-	windowedDepthMeasurements := groupIntoWindows(depthMeasurements, WindowSize)
-
-	summedWindows := sumWindowedDepthMeasurements(windowedDepthMeasurements)
-
-	depthMeasurementIncreaseCount := countDepthIncreases(summedWindows)
+	depthMeasurementIncreaseCount := countDepthIncreases("puzzle-input.dat", WindowSize)
 
 	channel <- Result{
 		answer: depthMeasurementIncreaseCount,
@@ -83,52 +78,56 @@ func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
 	waitGroup.Done()
 }
 
-func countDepthIncreases(depthMeasurements []int) int {
-	depthMeasurementIncreaseCount := 0
-	depthMeasurementCount := len(depthMeasurements)
-	for i := 1; i < depthMeasurementCount; i++ {
-		if depthMeasurements[i-1] < depthMeasurements[i] {
-			depthMeasurementIncreaseCount = depthMeasurementIncreaseCount + 1
-		}
+// countDepthIncreases
+// Streams the measurements through a window, comparing each full window's sum with the one before it.
+// Only the window is ever held in memory, so the input can be as long as it likes.
+func countDepthIncreases(filename string, windowSize int) int {
+	fd, err := os.Open(filename)
+	if err != nil {
+		panic(fmt.Sprintf("open %s: %v", filename, err))
+	}
+
+	depthMeasurementIncreaseCount, err := streamDepthIncreases(bufio.NewScanner(fd), windowSize)
+	if err != nil {
+		panic(fmt.Sprintf("read %s: %v", filename, err))
+	}
+
+	err = fd.Close()
+	if err != nil {
+		fmt.Println(fmt.Errorf("error closing file: %s: %v", filename, err))
 	}
 
 	return depthMeasurementIncreaseCount
 }
 
-func sumWindowedDepthMeasurements(measurements [][]int) []int {
-	var sums []int
-	for _, group := range measurements {
-		sum := 0
+// streamDepthIncreases skips blank lines but stops at the first line that is not a measurement.
+func streamDepthIncreases(scanner *bufio.Scanner, windowSize int) (int, error) {
+	depthMeasurementIncreaseCount := 0
+	window := collections.NewSlidingWindow[int](windowSize)
+	previousSum := 0
 
-		for _, measurement := range group {
-			sum = sum + measurement
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			continue
 		}
 
-		sums = append(sums, sum)
-	}
-
-	return sums
-}
+		measurement, err := strconv.Atoi(text)
+		if err != nil {
+			return depthMeasurementIncreaseCount, fmt.Errorf("line %v: %w", line, err)
+		}
 
-func groupIntoWindows(measurements []int, windowSize int) [][]int {
-	var windowed [][]int
-	measurementCount := len(measurements)
-	for i := 0; i < measurementCount-windowSize+1; i++ {
-		lastIndex := i + windowSize
-		windowed = append(windowed, measurements[i:lastIndex])
-	}
+		window.Push(measurement)
+		if !window.Full() {
+			continue
+		}
 
-	return windowed
-}
+		if window.Pushed() > windowSize && previousSum < window.Sum() {
+			depthMeasurementIncreaseCount = depthMeasurementIncreaseCount + 1
+		}
 
-func loadPuzzleInput() []int {
-	filename := "puzzle-input.dat"
-	strings := support.ReadFileIntoLines(filename)
-	var numbers []int
-	for _, value := range strings {
-		number, _ := strconv.Atoi(value)
-		numbers = append(numbers, number)
+		previousSum = window.Sum()
 	}
 
-	return numbers
+	return depthMeasurementIncreaseCount, scanner.Err()
 }
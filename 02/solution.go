@@ -1,12 +1,11 @@
 package main
 
 import (
-	"bufio"
+	"advent-of-code-2021/utility/geometry"
 	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,8 +20,6 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
-	_ = loadPuzzleInput()
-
 	waitCount := 3
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -49,6 +46,11 @@ func main() {
 }
 
 func doExamples(waitGroup *sync.WaitGroup) {
+	log.
+		Info().
+		Int("part-one-answer", FindSolutionForInput("example-input.dat", SteerByDepth)).
+		Int("part-two-answer", FindSolutionForInput("example-input.dat", SteerByAim)).
+		Msg("Example Data")
 
 	waitGroup.Done()
 }
@@ -56,83 +58,72 @@ func doExamples(waitGroup *sync.WaitGroup) {
 func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
-	x := 0
-	z := 0
-
-	filename := "puzzle-input.dat"
-	fd, err := os.Open(filename)
-	if err != nil {
-		panic(fmt.Sprintf("open %s: %v", filename, err))
-	}
-
-	scanner := bufio.NewScanner(fd)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Fields(line)
-
-		verb := parts[0]
-		value, _ := strconv.Atoi(parts[1])
-		switch verb {
-		case "forward":
-			x += value
-		case "down":
-			z += value
-		case "up":
-			z -= value
-		}
+	channel <- Result{
+		answer:   FindSolutionForInput("puzzle-input.dat", SteerByDepth),
+		duration: time.Since(start).Nanoseconds(),
 	}
+	waitGroup.Done()
+}
 
-	err = fd.Close()
-	if err != nil {
-		fmt.Println(fmt.Errorf("error closing file: %s: %v", filename, err))
-	}
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+	start := time.Now()
 
 	channel <- Result{
-		answer:   x * z,
+		answer:   FindSolutionForInput("puzzle-input.dat", SteerByAim),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
-	start := time.Now()
+// The submarine is a turtle heading East, so X is the horizontal position and Y the depth, with down going deeper.
+// The two parts only differ in what up and down mean.
 
-	x := 0
-	z := 0
-	a := 0
+type Steering func(direction geometry.Direction, value int) geometry.TurtleCommand
 
-	filename := "puzzle-input.dat"
-	fd, err := os.Open(filename)
-	if err != nil {
-		panic(fmt.Sprintf("open %s: %v", filename, err))
-	}
+// SteerByDepth moves the submarine straight up or down.
+func SteerByDepth(direction geometry.Direction, value int) geometry.TurtleCommand {
+	return func(turtle *geometry.Turtle) { turtle.Move(direction, value) }
+}
 
-	scanner := bufio.NewScanner(fd)
-	for scanner.Scan() {
-		line := scanner.Text()
+// SteerByAim tilts the submarine, changing how far it dives with each step forward.
+func SteerByAim(direction geometry.Direction, value int) geometry.TurtleCommand {
+	return func(turtle *geometry.Turtle) { turtle.AdjustAim(direction.Delta().Y * value) }
+}
+
+func ParseCourse(lines []string, steer Steering) []geometry.TurtleCommand {
+	var commands []geometry.TurtleCommand
+
+	for _, line := range lines {
 		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			continue
+		}
 
 		verb := parts[0]
 		value, _ := strconv.Atoi(parts[1])
-		switch verb {
-		case "forward":
-			x += value
-			z += a * value
-		case "down":
-			a += value
-		case "up":
-			a -= value
+
+		if verb == "forward" {
+			commands = append(commands, func(turtle *geometry.Turtle) { turtle.Forward(value) })
+			continue
 		}
-	}
 
-	channel <- Result{
-		answer:   x * z,
-		duration: time.Since(start).Nanoseconds(),
+		direction, err := geometry.ParseDirection(verb)
+		if err != nil {
+			panic(fmt.Sprintf("parse course: %v", err))
+		}
+		commands = append(commands, steer(direction, value))
 	}
-	waitGroup.Done()
+
+	return commands
+}
+
+func FindSolutionForInput(filename string, steer Steering) int {
+	submarine := geometry.NewTurtle(geometry.NewCoordinate(0, 0), geometry.East)
+	submarine.Run(ParseCourse(loadPuzzleInput(filename), steer))
+
+	return submarine.Position.X * submarine.Position.Y
 }
 
-func loadPuzzleInput() string {
-	filename := "example-input.dat"
-	return support.ReadFile(filename)
+func loadPuzzleInput(filename string) []string {
+	return support.ReadFileIntoLines(filename)
 }
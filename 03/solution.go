@@ -1,17 +1,21 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/bits"
+	"errors"
+	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"math"
+	"math/big"
 	"sync"
 	"time"
 )
 
 type Result struct {
-	answer   int
+	answer   *big.Int
 	duration int64
+	err      error
 }
 
 func main() {
@@ -35,58 +39,40 @@ func main() {
 
 	waitGroup.Wait()
 
-	log.
+	event := log.
 		Info().
-		Int("part-one-answer", partOneResult.answer).
-		Int64("part-one-duration", partOneResult.duration).
-		Int("part-two-answer", partTwoResult.answer).
-		Int64("part-two-duration", partTwoResult.duration).
-		Msg("day 02")
-}
-
-func findMostCommonValueFor(input []string, index int) byte {
-	var occurrences = make(map[byte]int)
-
-	occurrences['0'] = 0
-	occurrences['1'] = 0
-
-	for _, number := range input {
-		occurrences[number[index]]++
-	}
-
-	if occurrences['0'] == occurrences['1'] {
-		return '1'
-	} else if occurrences['0'] < occurrences['1'] {
-		return '1'
+		Str("part-one-answer", partOneResult.answer.String()).
+		Int64("part-one-duration", partOneResult.duration)
+	if partTwoResult.err != nil {
+		event = event.Str("part-two-error", partTwoResult.err.Error())
 	} else {
-		return '0'
+		event = event.
+			Str("part-two-answer", partTwoResult.answer.String()).
+			Int64("part-two-duration", partTwoResult.duration)
 	}
+	event.Msg("day 02")
 }
 
-func findLeastCommonValueFor(input []string, index int) byte {
-	var occurrences = make(map[byte]int)
-
-	occurrences['0'] = 0
-	occurrences['1'] = 0
-
+// findMostCommonValueFor counts index from the left, as the diagnostic report is written; ties go to 1.
+func findMostCommonValueFor(input []bits.BitSet, index int) bool {
+	ones := 0
 	for _, number := range input {
-		occurrences[number[index]]++
+		if number.Get(number.Width() - 1 - index) {
+			ones++
+		}
 	}
+	return ones*2 >= len(input)
+}
 
-	if occurrences['0'] == occurrences['1'] {
-		return '0'
-	} else if occurrences['0'] < occurrences['1'] {
-		return '0'
-	} else {
-		return '1'
-	}
+func findLeastCommonValueFor(input []bits.BitSet, index int) bool {
+	return !findMostCommonValueFor(input, index)
 }
 
-func filterByValueAtIndex(input []string, index int, value byte) []string {
-	var filtered []string
+func filterByValueAtIndex(input []bits.BitSet, index int, value bool) []bits.BitSet {
+	var filtered []bits.BitSet
 
 	for _, number := range input {
-		if number[index] == value {
+		if number.Get(number.Width()-1-index) == value {
 			filtered = append(filtered, number)
 		}
 	}
@@ -94,104 +80,81 @@ func filterByValueAtIndex(input []string, index int, value byte) []string {
 	return filtered
 }
 
-func findOxygenGeneratorRating(input []string) string {
-	width := len(input[0])
-	for index := 0; index < width; index++ {
-		mostCommonValue := findMostCommonValueFor(input, index)
-		input = filterByValueAtIndex(input, index, mostCommonValue)
-		if len(input) == 1 {
-			return input[0]
-		}
+// findRating keeps only the numbers that match criteria at each index in turn, until at most one is left.
+// There is only a rating if exactly one number is left in the end. Duplicate numbers can mean none are left, or that
+// several are left once every index has been checked.
+func findRating(input []bits.BitSet, criteria func(input []bits.BitSet, index int) bool) (bits.BitSet, bool) {
+	width := input[0].Width()
+	for index := 0; index < width && len(input) > 1; index++ {
+		input = filterByValueAtIndex(input, index, criteria(input, index))
 	}
 
-	return ""
-}
-
-func findC02ScrubberRating(input []string) string {
-	width := len(input[0])
-	for index := 0; index < width; index++ {
-		leastCommonValue := findLeastCommonValueFor(input, index)
-		input = filterByValueAtIndex(input, index, leastCommonValue)
-		if len(input) == 1 {
-			return input[0]
-		}
+	if len(input) != 1 {
+		return bits.New(width), false
 	}
 
-	return ""
+	return input[0], true
 }
 
-func calculateGamma(input []string) int {
-	width := len(input[0])
-	power := width
-	gamma := 0
-
-	for index := 0; index < width; index++ {
-		power--
-
-		var occurrences = make(map[byte]int)
+func findOxygenGeneratorRating(input []bits.BitSet) (bits.BitSet, bool) {
+	return findRating(input, findMostCommonValueFor)
+}
 
-		occurrences['0'] = 0
-		occurrences['1'] = 0
+func findC02ScrubberRating(input []bits.BitSet) (bits.BitSet, bool) {
+	return findRating(input, findLeastCommonValueFor)
+}
 
-		for _, number := range input {
-			occurrences[number[index]]++
-		}
+// calculateLifeSupportRating multiplies the oxygen generator and CO2 scrubber ratings, failing if either is missing.
+func calculateLifeSupportRating(input []bits.BitSet) (*big.Int, error) {
+	oxygenGeneratorRating, found := findOxygenGeneratorRating(input)
+	if !found {
+		return nil, errors.New("the diagnostic report has no single oxygen generator rating")
+	}
 
-		if findMostCommonValueFor(input, index) == '1' {
-			additive := int(math.Pow(2, float64(power)))
-			gamma = gamma + additive
-		}
+	c02ScrubberRating, found := findC02ScrubberRating(input)
+	if !found {
+		return nil, errors.New("the diagnostic report has no single CO2 scrubber rating")
 	}
 
-	return gamma
+	return multiply(oxygenGeneratorRating, c02ScrubberRating), nil
 }
 
-func calculateEpsilon(input []string) int {
-	width := len(input[0])
-	power := width
-	epsilon := 0
+func calculateGamma(input []bits.BitSet) bits.BitSet {
+	width := input[0].Width()
+	gamma := bits.New(width)
 
-	for index := 0; index < width; index++ {
-		power--
-
-		var occurrences = make(map[byte]int)
-
-		occurrences['0'] = 0
-		occurrences['1'] = 0
+	for position, ones := range bits.CountOnes(input) {
+		gamma.Set(position, ones*2 >= len(input))
+	}
 
-		for _, number := range input {
-			occurrences[number[index]]++
-		}
+	return gamma
+}
 
-		if findMostCommonValueFor(input, index) == '0' {
-			additive := int(math.Pow(2, float64(power)))
-			epsilon = epsilon + additive
-		}
-	}
+func calculateEpsilon(input []bits.BitSet) bits.BitSet {
+	return calculateGamma(input).Not()
+}
 
-	return epsilon
+func multiply(a, b bits.BitSet) *big.Int {
+	return new(big.Int).Mul(a.BigInt(), b.BigInt())
 }
 
 func doExamples(waitGroup *sync.WaitGroup) {
-	exampleData := support.ReadFileIntoLines("example-input.dat")
+	exampleData := parseDiagnosticReport(support.ReadFileIntoLines("example-input.dat"))
 
 	gamma := calculateGamma(exampleData)
 	epsilon := calculateEpsilon(exampleData)
 
-	oxygenGeneratorRating := findOxygenGeneratorRating(exampleData)
-	c02ScrubberRating := findC02ScrubberRating(exampleData)
-
-	oxy := toDecimal(oxygenGeneratorRating)
-	c02 := toDecimal(c02ScrubberRating)
+	lifeSupportRating, err := calculateLifeSupportRating(exampleData)
+	if err != nil {
+		log.Error().Err(err).Msg("Example Data")
+	}
 
 	log.
 		Info().
-		Int("gamma", gamma).
-		Int("epsilon", epsilon).
-		Int("part-one-answer", gamma*epsilon).
-		Str("oxygenGeneratorRating", oxygenGeneratorRating).
-		Str("c02ScrubberRating", c02ScrubberRating).
-		Int("part-two-answer", oxy*c02).
+		Str("gamma", gamma.BigInt().String()).
+		Str("epsilon", epsilon.BigInt().String()).
+		Str("part-one-answer", multiply(gamma, epsilon).String()).
+		Str("part-two-answer", lifeSupportRating.String()).
 		Msg("Example Data")
 
 	waitGroup.Done()
@@ -205,44 +168,45 @@ func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	epsilon := calculateEpsilon(diagnosticReport)
 
 	channel <- Result{
-		answer:   gamma * epsilon,
+		answer:   multiply(gamma, epsilon),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func toDecimal(binary string) int {
-	power := len(binary)
-	decimal := 0
-
-	for _, value := range binary {
-		power--
-		if value == '1' {
-			decimal += int(math.Pow(2, float64(power)))
-		}
-	}
-
-	return decimal
-}
-
 func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
-	diagnosticReport := loadPuzzleInput()
-	oxygenGeneratorRating := findOxygenGeneratorRating(diagnosticReport)
-	c02ScrubberRating := findC02ScrubberRating(diagnosticReport)
-
-	oxygen := toDecimal(oxygenGeneratorRating)
-	co2 := toDecimal(c02ScrubberRating)
+	lifeSupportRating, err := calculateLifeSupportRating(loadPuzzleInput())
+	if err != nil {
+		log.Error().Err(err).Msg("part two")
+	}
 
 	channel <- Result{
-		answer:   oxygen * co2,
+		answer:   lifeSupportRating,
 		duration: time.Since(start).Nanoseconds(),
+		err:      err,
 	}
 	waitGroup.Done()
 }
 
-func loadPuzzleInput() []string {
+func parseDiagnosticReport(lines []string) []bits.BitSet {
+	var report []bits.BitSet
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		number, err := bits.Parse(line)
+		if err != nil {
+			panic(fmt.Sprintf("parse diagnostic report: %v", err))
+		}
+		report = append(report, number)
+	}
+	return report
+}
+
+func loadPuzzleInput() []bits.BitSet {
 	filename := "puzzle-input.dat"
-	return support.ReadFileIntoLines(filename)
+	return parseDiagnosticReport(support.ReadFileIntoLines(filename))
 }
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/render"
+	"flag"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"math"
+	"image/color"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,44 +19,8 @@ import (
 	Solution implementation
 */
 
-type Point struct {
-	x int
-	y int
-}
-
 type Line struct {
-	start              Point
-	end                Point
-	intermediatePoints []Point
-}
-
-func (l *Line) CalculateMovementSteps() (int, int, int) {
-	deltaX := l.end.x - l.start.x
-	deltaY := l.end.y - l.start.y
-	absDeltaX := int(math.Abs(float64(deltaX)))
-	absDeltaY := int(math.Abs(float64(deltaY)))
-
-	xm, ym, count := 0, 0, 0
-
-	if deltaX == 0 {
-		xm = deltaX
-		ym = deltaY / absDeltaY
-		count = absDeltaY
-	} else if deltaY == 0 {
-		xm = deltaX / absDeltaX
-		ym = deltaY
-		count = absDeltaX
-	} else if deltaX > deltaY {
-		xm = deltaX / absDeltaX
-		ym = deltaY / absDeltaX
-		count = absDeltaX
-	} else {
-		xm = deltaX / absDeltaY
-		ym = deltaY / absDeltaY
-		count = absDeltaY
-	}
-
-	return xm, ym, count
+	segment geometry.Segment
 }
 
 func (l *Line) IsDiagonal() bool {
@@ -60,26 +28,25 @@ func (l *Line) IsDiagonal() bool {
 }
 
 func (l *Line) IsHorizontal() bool {
-	return l.start.y == l.end.y
+	return l.segment.IsHorizontal()
 }
 
 func (l *Line) IsVertical() bool {
-	return l.start.x == l.end.x
+	return l.segment.IsVertical()
 }
 
 func (l *Line) ParseLine(input string) {
-	parsePoint := func(points string) Point {
+	parsePoint := func(points string) geometry.Coordinate {
 		coordinates := strings.Split(points, ",")
 		x, _ := strconv.Atoi(coordinates[0])
 		y, _ := strconv.Atoi(coordinates[1])
 
-		return Point{x: x, y: y}
+		return geometry.NewCoordinate(x, y)
 	}
 
 	points := strings.Split(input, " -> ")
 
-	l.start = parsePoint(points[0])
-	l.end = parsePoint(points[1])
+	l.segment = geometry.NewSegment(parsePoint(points[0]), parsePoint(points[1]))
 }
 
 func Parse(input []string) []Line {
@@ -88,58 +55,157 @@ func Parse(input []string) []Line {
 	for _, inputLine := range input {
 		line := Line{}
 		line.ParseLine(inputLine)
-		line.PopulateIntermediatePoints()
 		lines = append(lines, line)
 	}
 
 	return lines
 }
 
-func (l *Line) Points() []Point {
-	var points []Point
-	points = append(points, l.start)
-	points = append(points, l.intermediatePoints...)
-	points = append(points, l.end)
-	return points
+// Points
+// Lines at any angle other than 45 degrees cover every cell they are drawn through, not just the ones they hit exactly.
+func (l *Line) Points() []geometry.Coordinate {
+	return l.segment.Rasterize()
 }
 
-func (l *Line) PopulateIntermediatePoints() {
-	xd, yd, count := l.CalculateMovementSteps()
-
-	x := l.start.x
-	y := l.start.y
+func AxisAligned(line Line) bool {
+	return !line.IsDiagonal()
+}
 
-	for n := 1; n < count; n++ {
-		x += xd
-		y += yd
-		l.intermediatePoints = append(l.intermediatePoints, Point{x: x, y: y})
-	}
+func AxisAlignedOrDiagonal(line Line) bool {
+	return AxisAligned(line) || line.segment.IsDiagonal()
 }
 
-func FindSolutionForInput(filename string, includeDiagonals bool) int {
-	puzzleInput := loadPuzzleInput(filename)
-	lines := Parse(puzzleInput)
+func AnyAngle(_ Line) bool {
+	return true
+}
 
-	var points = make(map[Point]int)
+// CountOverlapsByRasterizing tallies every point of every line.
+func CountOverlapsByRasterizing(lines []Line, threshold int) int {
+	points := collections.NewSparseGrid[int]()
+	increment := func(count int, _ bool) int { return count + 1 }
 
 	for _, line := range lines {
-		if includeDiagonals || !line.IsDiagonal() {
-			for _, point := range line.Points() {
-				points[point]++
-			}
+		for _, point := range line.Points() {
+			points.Update(point, increment)
 		}
 	}
 
 	solution := 0
-	for _, point := range points {
-		if point >= 2 {
+	points.ForEach(func(_ geometry.Coordinate, count int) {
+		if count >= threshold {
 			solution++
 		}
+	})
+
+	return solution
+}
+
+// CountOverlapsBySweeping never visits the points themselves, so line length does not matter, but it cannot handle
+// lines at angles other than 45 degrees.
+func CountOverlapsBySweeping(lines []Line, threshold int) int {
+	segments := make([]geometry.Segment, len(lines))
+	for index, line := range lines {
+		segments[index] = line.segment
+	}
+
+	solution, err := geometry.CountCovered(segments, threshold)
+	if err != nil {
+		log.Fatal().Err(err).Msg("counting overlaps by sweeping")
 	}
 
 	return solution
 }
 
+func FindSolutionForInput(filename string, include func(line Line) bool, countOverlaps func(lines []Line) int) int {
+	puzzleInput := loadPuzzleInput(filename)
+
+	var lines []Line
+	for _, line := range Parse(puzzleInput) {
+		if include(line) {
+			lines = append(lines, line)
+		}
+	}
+
+	return countOverlaps(lines)
+}
+
+// RenderOverlaps draws the lines a batch at a time, those part one counts before those only part two includes, with
+// each point glowing brighter the more lines cover it.
+func RenderOverlaps(filename string, output string, includeInPartTwo func(line Line) bool) error {
+	var lines, partTwoLines []Line
+	var endpoints []geometry.Coordinate
+	for _, line := range Parse(loadPuzzleInput(filename)) {
+		switch {
+		case AxisAligned(line):
+			lines = append(lines, line)
+		case includeInPartTwo(line):
+			partTwoLines = append(partTwoLines, line)
+		default:
+			continue
+		}
+		endpoints = append(endpoints, line.segment.Start, line.segment.End)
+	}
+	lines = append(lines, partTwoLines...)
+
+	heat := render.Gradient(color.RGBA{R: 64, G: 0, B: 32, A: 255}, color.RGBA{R: 255, G: 230, B: 120, A: 255}, 5)
+	palette := append(color.Palette{color.Black}, heat...)
+
+	points := collections.NewSparseGrid[int]()
+	increment := func(count int, _ bool) int { return count + 1 }
+	paint := func(coordinate geometry.Coordinate) color.Color {
+		count, _ := points.Get(coordinate)
+		if count >= len(palette) {
+			return palette[len(palette)-1]
+		}
+		return palette[count]
+	}
+
+	animation := render.NewAnimation(palette, 20)
+	region := geometry.BoundingRect(endpoints)
+	batchSize := len(lines)/20 + 1
+	for index, line := range lines {
+		for _, point := range line.Points() {
+			points.Update(point, increment)
+		}
+
+		if (index+1)%batchSize == 0 || index == len(lines)-1 {
+			if err := animation.AddFrame(render.Draw(region, 1, paint)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return animation.Save(output)
+}
+
+// DrawLines draws the lines part one counts in blue and those only part two includes in red, optionally numbering each
+// line at its start by where it comes in the input.
+func DrawLines(puzzleInput []string, includeInPartTwo func(line Line) bool, labels bool) render.SVG {
+	var endpoints []geometry.Coordinate
+	lines := Parse(puzzleInput)
+	for _, line := range lines {
+		endpoints = append(endpoints, line.segment.Start, line.segment.End)
+	}
+
+	svg := render.NewSVG(geometry.BoundingRect(endpoints).Expand(1), 1)
+	for index, line := range lines {
+		switch {
+		case AxisAligned(line):
+			svg.Line(line.segment.Start, line.segment.End, render.Style{Stroke: "#1f77b4", StrokeWidth: 1})
+		case includeInPartTwo(line):
+			svg.Line(line.segment.Start, line.segment.End, render.Style{Stroke: "#d62728", StrokeWidth: 1})
+		default:
+			continue
+		}
+
+		if labels {
+			svg.Text(line.segment.Start, strconv.Itoa(index+1), render.Style{FontSize: 8})
+		}
+	}
+
+	return svg
+}
+
 /*
 	Main
 */
@@ -152,6 +218,41 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	anyAngle := flag.Bool("any-angle", false, "include lines at any angle in part two, not just those at 45 degrees")
+	useSweep := flag.Bool("sweep", false, "count overlaps with a sweep along each line instead of tallying every point; not with -any-angle")
+	threshold := flag.Int("threshold", 2, "the number of lines a point must be on to count as an overlap")
+	renderTo := flag.String("render", "", "draw the puzzle input's part two lines piling up to this GIF (or, ending in .png, all of them)")
+	svgTo := flag.String("svg", "", "draw the puzzle input's part two lines to this SVG")
+	labels := flag.Bool("labels", false, "number each line in the SVG by where it comes in the input")
+	flag.Parse()
+
+	if *anyAngle && *useSweep {
+		log.Fatal().Msg("-sweep only handles lines at 45 degrees, so it cannot be used with -any-angle")
+	}
+
+	includeInPartTwo := AxisAlignedOrDiagonal
+	if *anyAngle {
+		includeInPartTwo = AnyAngle
+	}
+
+	if len(*renderTo) > 0 {
+		if err := RenderOverlaps("puzzle-input.dat", *renderTo, includeInPartTwo); err != nil {
+			log.Error().Err(err).Str("filename", *renderTo).Msg("rendering")
+		}
+	}
+
+	if len(*svgTo) > 0 {
+		svg := DrawLines(loadPuzzleInput("puzzle-input.dat"), includeInPartTwo, *labels)
+		if err := svg.Save(*svgTo); err != nil {
+			log.Error().Err(err).Str("filename", *svgTo).Msg("drawing")
+		}
+	}
+
+	countOverlaps := func(lines []Line) int { return CountOverlapsByRasterizing(lines, *threshold) }
+	if *useSweep {
+		countOverlaps = func(lines []Line) int { return CountOverlapsBySweeping(lines, *threshold) }
+	}
+
 	waitCount := 3
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -160,9 +261,9 @@ func main() {
 	partOneChannel := make(chan Result)
 	partTwoChannel := make(chan Result)
 
-	go doExamples(exampleChannel, &waitGroup)
-	go doPartOne(partOneChannel, &waitGroup)
-	go doPartTwo(partTwoChannel, &waitGroup)
+	go doExamples(exampleChannel, &waitGroup, includeInPartTwo, countOverlaps)
+	go doPartOne(partOneChannel, &waitGroup, countOverlaps)
+	go doPartTwo(partTwoChannel, &waitGroup, includeInPartTwo, countOverlaps)
 
 	exampleResult := <-exampleChannel
 	partOneResult := <-partOneChannel
@@ -185,31 +286,31 @@ func main() {
 	Executors
 */
 
-func doExamples(channel chan Result, waitGroup *sync.WaitGroup) {
+func doExamples(channel chan Result, waitGroup *sync.WaitGroup, include func(line Line) bool, countOverlaps func(lines []Line) int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat", true),
+		answer:   FindSolutionForInput("example-input.dat", include, countOverlaps),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartOne(channel chan Result, waitGroup *sync.WaitGroup, countOverlaps func(lines []Line) int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat", false),
+		answer:   FindSolutionForInput("puzzle-input.dat", AxisAligned, countOverlaps),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup, include func(line Line) bool, countOverlaps func(lines []Line) int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat", true),
+		answer:   FindSolutionForInput("puzzle-input.dat", include, countOverlaps),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
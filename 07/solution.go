@@ -1,6 +1,7 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/intervals"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -24,25 +25,31 @@ func CalculateTriangularFuelConsumption(count int) int {
 	return ((count + 1) * count) / 2
 }
 
+// FindSolutionForInput finds the cheapest position by repeatedly halving the range rather than trying every position in
+// it. That works because each crab's fuel use is convex in the position, so the total is convex too.
 func FindSolutionForInput(filename string, fuelConsumptionCalculation func(int) int) int {
 	puzzleInput := loadPuzzleInput(filename)
 	sort.Ints(puzzleInput)
 
-	min := puzzleInput[0]
-	max := puzzleInput[len(puzzleInput)-1]
-
-	differences := []int{}
-	for position := min; position < max; position++ {
+	totalFuel := func(position int) int {
 		accumulator := 0
 		for _, value := range puzzleInput {
 			accumulator += fuelConsumptionCalculation(int(math.Abs(float64(value - position))))
 		}
-		differences = append(differences, accumulator)
+		return accumulator
 	}
 
-	sort.Ints(differences)
+	positions := intervals.NewInterval(puzzleInput[0], puzzleInput[len(puzzleInput)-1])
+	for positions.Len() > 1 {
+		middle := positions.Min + (positions.Len()-1)/2
+		if totalFuel(middle) <= totalFuel(middle+1) {
+			positions.Max = middle
+		} else {
+			positions.Min = middle + 1
+		}
+	}
 
-	return differences[0]
+	return totalFuel(positions.Min)
 }
 
 /*
@@ -1,14 +1,15 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/collections"
 	"advent-of-code-2021/utility/geometry"
-	"fmt"
+	"advent-of-code-2021/utility/render"
+	"flag"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"image/color"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
@@ -17,29 +18,14 @@ import (
 	Solution implementation
 */
 
-const highestPoint = "9"
+const highestPoint = 9
 
 type FloorMap struct {
-	dimX int
-	dimY int
-
-	heights      [][]int
+	heights      collections.Grid[int]
 	lowestPoints map[geometry.Coordinate]int
 	basins       []int
 }
 
-func (fm *FloorMap) Append(heights string) *FloorMap {
-	yi := len(fm.heights)
-	fm.heights = append(fm.heights, []int{})
-
-	for _, height := range heights {
-		value, _ := strconv.Atoi(string(height))
-		fm.heights[yi] = append(fm.heights[yi], value)
-	}
-
-	return fm
-}
-
 func (fm *FloorMap) CalculateBasinSizeProduct() int {
 	accumulator := 1
 	sort.Sort(sort.Reverse(sort.IntSlice(fm.basins)))
@@ -59,12 +45,9 @@ func (fm *FloorMap) CalculateRiskSum() int {
 }
 
 func (fm *FloorMap) FindLowestPoints() *FloorMap {
-	for yi := 1; yi <= fm.dimY; yi++ {
-		for xi := 1; xi <= fm.dimX; xi++ {
-			coordinate := geometry.NewCoordinate(xi, yi)
-			if fm.IsLowestPoint(coordinate) {
-				fm.lowestPoints[coordinate] = 1 + fm.HeightAt(coordinate)
-			}
+	for _, coordinate := range fm.heights.Coordinates() {
+		if fm.IsLowestPoint(coordinate) {
+			fm.lowestPoints[coordinate] = 1 + fm.HeightAt(coordinate)
 		}
 	}
 
@@ -72,11 +55,11 @@ func (fm *FloorMap) FindLowestPoints() *FloorMap {
 }
 
 func (fm *FloorMap) HeightAt(coordinate geometry.Coordinate) int {
-	return fm.heights[coordinate.Y][coordinate.X]
+	return fm.heights.Get(coordinate)
 }
 
 func (fm *FloorMap) IsLowestPoint(coordinate geometry.Coordinate) bool {
-	adjacentCoordinates := coordinate.Adjacent()
+	adjacentCoordinates := fm.heights.Adjacent(coordinate)
 
 	accumulator := 0
 
@@ -90,11 +73,9 @@ func (fm *FloorMap) IsLowestPoint(coordinate geometry.Coordinate) bool {
 }
 
 func (fm *FloorMap) MapBasins() *FloorMap {
-	HighestPoint, _ := strconv.Atoi(highestPoint)
-
 	mapBasin := func(coordinate geometry.Coordinate) int {
 		// choosing the size of the buffered channel is ham-fisted at this point
-		coordinatesChannel := make(chan geometry.Coordinate, (fm.dimX*fm.dimY)/2)
+		coordinatesChannel := make(chan geometry.Coordinate, fm.heights.Size()/2)
 		accumulator := 0
 		var visited = make(map[geometry.Coordinate]bool)
 		coordinatesChannel <- coordinate
@@ -110,8 +91,8 @@ func (fm *FloorMap) MapBasins() *FloorMap {
 
 				accumulator++
 
-				for _, adjacent := range coordinate.Adjacent() {
-					if fm.HeightAt(adjacent) != HighestPoint {
+				for _, adjacent := range fm.heights.Adjacent(coordinate) {
+					if fm.HeightAt(adjacent) != highestPoint {
 						coordinatesChannel <- adjacent
 					}
 				}
@@ -128,41 +109,47 @@ func (fm *FloorMap) MapBasins() *FloorMap {
 	return fm
 }
 
-func (fm *FloorMap) Print() {
-	for yi := 0; yi <= fm.dimY+1; yi++ {
-		for xi := 0; xi <= fm.dimX+1; xi++ {
-			fmt.Printf("%v ", fm.heights[yi][xi])
-		}
-		fmt.Print("\n")
+// MapBasinsWithDisjointSet
+// Unions every non-9 location with its non-9 neighbours; each resulting component is a basin.
+// No lowest points required.
+func (fm *FloorMap) MapBasinsWithDisjointSet() *FloorMap {
+	for _, basin := range fm.basinComponents() {
+		fm.basins = append(fm.basins, len(basin))
 	}
-}
 
-// NewFloorMap
-// This creates a 'border' around the input.
-// That border is filled with 9s so the introduced values will not affect the determination of the lowest point.
-// What it WILL do is relieve the burden of bounds checking. Yay.
-func NewFloorMap(puzzleInput []string) FloorMap {
-	floorMap := FloorMap{
-		dimY: len(puzzleInput),
-		dimX: len(puzzleInput[0]),
+	return fm
+}
 
-		heights:      make([][]int, 0),
-		lowestPoints: make(map[geometry.Coordinate]int),
-		basins:       []int{},
-	}
+func (fm *FloorMap) basinComponents() [][]geometry.Coordinate {
+	basins := collections.NewDisjointSet[geometry.Coordinate]()
 
-	rowOfNines := strings.Repeat(highestPoint, floorMap.dimX+2)
+	for _, coordinate := range fm.heights.Coordinates() {
+		if fm.HeightAt(coordinate) == highestPoint {
+			continue
+		}
 
-	floorMap.Append(rowOfNines)
+		basins.Add(coordinate)
 
-	for _, line := range puzzleInput {
-		borderedLine := fmt.Sprintf("%v%v%v", highestPoint, line, highestPoint)
-		floorMap.Append(borderedLine)
+		for _, adjacent := range fm.heights.Adjacent(coordinate) {
+			if fm.HeightAt(adjacent) != highestPoint {
+				basins.Union(coordinate, adjacent)
+			}
+		}
 	}
 
-	floorMap.Append(rowOfNines)
+	return basins.Components()
+}
 
-	return floorMap
+func (fm *FloorMap) Print() {
+	fm.heights.Print()
+}
+
+func NewFloorMap(puzzleInput []string) FloorMap {
+	return FloorMap{
+		heights:      collections.ParseDigitGrid(puzzleInput),
+		lowestPoints: make(map[geometry.Coordinate]int),
+		basins:       []int{},
+	}
 }
 
 func CalculatePartOneSolution(floorMap FloorMap) int {
@@ -175,6 +162,10 @@ func CalculatePartTwoSolution(floorMap FloorMap) int {
 	return floorMap.FindLowestPoints().MapBasins().CalculateBasinSizeProduct()
 }
 
+func CalculatePartTwoSolutionWithDisjointSet(floorMap FloorMap) int {
+	return floorMap.MapBasinsWithDisjointSet().CalculateBasinSizeProduct()
+}
+
 func FindSolutionForInput(filename string, calculateSolution func(floorMap FloorMap) int) int {
 	solution := 0
 
@@ -186,6 +177,63 @@ func FindSolutionForInput(filename string, calculateSolution func(floorMap Floor
 	return solution
 }
 
+// RenderBasins draws the heights in shades of grey, lowest darkest, then fills in the basins a batch at a time.
+// The three largest basins, the ones part two multiplies, are filled in last and in red.
+func RenderBasins(filename string, output string) error {
+	floorMap := NewFloorMap(loadPuzzleInput(filename))
+
+	basins := floorMap.basinComponents()
+	sort.SliceStable(basins, func(i, j int) bool { return len(basins[i]) < len(basins[j]) })
+
+	heights := render.Gradient(color.RGBA{R: 24, G: 24, B: 32, A: 255}, color.RGBA{R: 224, G: 224, B: 232, A: 255}, highestPoint+1)
+	basinColors := color.Palette{
+		color.RGBA{R: 70, G: 130, B: 180, A: 255},
+		color.RGBA{R: 46, G: 139, B: 87, A: 255},
+		color.RGBA{R: 218, G: 165, B: 32, A: 255},
+		color.RGBA{R: 106, G: 90, B: 205, A: 255},
+		color.RGBA{R: 0, G: 139, B: 139, A: 255},
+		color.RGBA{R: 205, G: 133, B: 63, A: 255},
+	}
+	largest := color.RGBA{R: 220, G: 20, B: 60, A: 255}
+	palette := append(append(append(color.Palette{}, heights...), basinColors...), largest)
+
+	filled := make(map[geometry.Coordinate]color.Color)
+	paint := func(coordinate geometry.Coordinate) color.Color {
+		if basinColor, found := filled[coordinate]; found {
+			return basinColor
+		}
+		return heights[floorMap.HeightAt(coordinate)]
+	}
+
+	animation := render.NewAnimation(palette, 10)
+	addFrame := func() error {
+		return animation.AddFrame(render.Draw(floorMap.heights.Bounds(), 4, paint))
+	}
+
+	if err := addFrame(); err != nil {
+		return err
+	}
+
+	batchSize := len(basins)/20 + 1
+	for index, basin := range basins {
+		basinColor := color.Color(basinColors[index%len(basinColors)])
+		if index >= len(basins)-3 {
+			basinColor = largest
+		}
+		for _, coordinate := range basin {
+			filled[coordinate] = basinColor
+		}
+
+		if (index+1)%batchSize == 0 || index == len(basins)-1 {
+			if err := addFrame(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return animation.Save(output)
+}
+
 /*
 	Main
 */
@@ -198,6 +246,21 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	useDisjointSet := flag.Bool("disjoint-set", false, "map basins with a disjoint set instead of flooding from each lowest point")
+	renderTo := flag.String("render", "", "draw the puzzle input's basins filling in to this GIF (or, ending in .png, the filled map)")
+	flag.Parse()
+
+	if len(*renderTo) > 0 {
+		if err := RenderBasins("puzzle-input.dat", *renderTo); err != nil {
+			log.Error().Err(err).Str("filename", *renderTo).Msg("rendering")
+		}
+	}
+
+	calculatePartTwoSolution := CalculatePartTwoSolution
+	if *useDisjointSet {
+		calculatePartTwoSolution = CalculatePartTwoSolutionWithDisjointSet
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -208,9 +271,9 @@ func main() {
 	partTwoChannel := make(chan Result)
 
 	go doExampleOne(exampleChannelOne, &waitGroup)
-	go doExampleTwo(exampleChannelTwo, &waitGroup)
+	go doExampleTwo(exampleChannelTwo, &waitGroup, calculatePartTwoSolution)
 	go doPartOne(partOneChannel, &waitGroup)
-	go doPartTwo(partTwoChannel, &waitGroup)
+	go doPartTwo(partTwoChannel, &waitGroup, calculatePartTwoSolution)
 
 	exampleResultOne := <-exampleChannelOne
 	exampleResultTwo := <-exampleChannelTwo
@@ -246,11 +309,11 @@ func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	waitGroup.Done()
 }
 
-func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup, calculateSolution func(floorMap FloorMap) int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat", CalculatePartTwoSolution),
+		answer:   FindSolutionForInput("example-input.dat", calculateSolution),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -266,11 +329,11 @@ func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	waitGroup.Done()
 }
 
-func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup, calculateSolution func(floorMap FloorMap) int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat", CalculatePartTwoSolution),
+		answer:   FindSolutionForInput("puzzle-input.dat", calculateSolution),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
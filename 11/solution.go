@@ -3,9 +3,15 @@ package main
 import (
 	"advent-of-code-2021/utility/collections"
 	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/render"
+	"flag"
+	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"image/color"
+	"io"
+	"os"
 	"sync"
 	"time"
 )
@@ -14,127 +20,152 @@ import (
 	Solution implementation
 */
 
-func FindSolutionForInput(filename string) int {
-	SentinelValue := -1
-	FlashPoint := 9
-	flashedCount := 0
+const FlashPoint = 9
+
+// MaxSteps is as many steps as are simulated waiting for every octopus to flash at once.
+const MaxSteps = 500
 
-	var flashedDuringStep []geometry.Coordinate
-	flashedMap := make(map[geometry.Coordinate]int)
+// Step raises every octopus's energy by one, lets those above FlashPoint flash, each at most once, raising their
+// neighbours in turn, and finally drops the ones that flashed back to zero. It returns how many flashed.
+func Step(grid *collections.Grid[int]) int {
+	flashed := make(map[geometry.Coordinate]bool)
+	var flashedDuringPass []geometry.Coordinate
 
-	setToZero := func(v int) int { return 0 }
 	incrementValue := func(coordinate geometry.Coordinate, v int) int { return v + 1 }
 	recordFlashed := func(coordinate geometry.Coordinate, energyLevel int) int {
-		_, found := flashedMap[coordinate]
-		if energyLevel > FlashPoint && !found {
-			flashedDuringStep = append(flashedDuringStep, coordinate)
-			flashedMap[coordinate]++
-			flashedCount++
+		if energyLevel > FlashPoint && !flashed[coordinate] {
+			flashedDuringPass = append(flashedDuringPass, coordinate)
+			flashed[coordinate] = true
 		}
 		return energyLevel
 	}
 
-	matrix := collections.NewBorderedIntMatrix()
-	puzzleInput := loadPuzzleInput(filename)
-	matrix.Populate(puzzleInput, SentinelValue)
+	grid.VisitEach(incrementValue)
 
-	for j := 0; j < 100; j++ {
+	for {
+		grid.VisitEach(recordFlashed)
+		if len(flashedDuringPass) == 0 {
+			break
+		}
+		for _, coordinate := range flashedDuringPass {
+			grid.VisitAllAdjacent(coordinate, incrementValue)
+		}
+		flashedDuringPass = []geometry.Coordinate{}
+	}
 
-		// stage 1
-		matrix.VisitEach(incrementValue)
+	for coordinate := range flashed {
+		grid.Set(coordinate, 0)
+	}
 
-		// stage 2
-		for {
-			matrix.VisitEach(recordFlashed)
-			matrix.ForEachAdjacentIn(flashedDuringStep, incrementValue)
-			if len(flashedDuringStep) == 0 {
-				break
-			}
-			flashedDuringStep = []geometry.Coordinate{}
-		}
+	return len(flashed)
+}
 
-		// stage 3
-		for k := range flashedMap {
-			flashedDuringStep = append(flashedDuringStep, k)
-		}
-		flashedMap = make(map[geometry.Coordinate]int)
-		matrix.ForEachIn(flashedDuringStep, setToZero)
+func FindSolutionForInput(filename string) int {
+	grid := collections.ParseDigitGrid(loadPuzzleInput(filename))
 
-		flashedDuringStep = []geometry.Coordinate{}
+	flashedCount := 0
+	for step := 0; step < 100; step++ {
+		flashedCount += Step(&grid)
 	}
 
 	return flashedCount
 }
 
+// FindSolutionForInput2 finds the first step in which every octopus flashes at once, giving up with 0 after MaxSteps.
 func FindSolutionForInput2(filename string) int {
-	SentinelValue := -1
-	FlashPoint := 9
-
-	puzzleInput := loadPuzzleInput(filename)
-	matrix := collections.NewBorderedIntMatrix()
-	matrix.Populate(puzzleInput, SentinelValue)
+	grid := collections.ParseDigitGrid(loadPuzzleInput(filename))
 
-	flashedCount := 0
-	zeroCount := 0
+	for step := 1; step <= MaxSteps; step++ {
+		if Step(&grid) == grid.Size() {
+			return step
+		}
+	}
 
-	var flashedDuringStep []geometry.Coordinate
-	flashedMap := make(map[geometry.Coordinate]int)
+	log.Warn().Str("filename", filename).Int("steps", MaxSteps).Msg("octopuses never all flashed at once")
+	return 0
+}
 
-	setToZero := func(v int) int { return 0 }
+// RenderSimulation draws a frame for every step up to, and a few beyond, the first in which every octopus flashes.
+func RenderSimulation(filename string, output string) error {
+	grid := collections.ParseDigitGrid(loadPuzzleInput(filename))
+
+	palette := energyPalette()
+	paint := func(energyLevel int) color.Color { return palette[energyLevel] }
+
+	animation := render.NewAnimation(palette, 10)
+	var err error
+	outcome := simulate(&grid, func() bool {
+		err = animation.AddFrame(render.DrawGrid[int](&grid, 8, paint))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
 
-	incrementValue := func(coordinate geometry.Coordinate, v int) int { return v + 1 }
+	if err := animation.Save(output); err != nil {
+		return err
+	}
 
-	recordFlashed := func(coordinate geometry.Coordinate, energyLevel int) int {
-		_, found := flashedMap[coordinate]
-		if energyLevel > FlashPoint && !found {
-			flashedDuringStep = append(flashedDuringStep, coordinate)
-			flashedMap[coordinate]++
-			flashedCount++
-		}
-		return energyLevel
+	if outcome == gaveUp {
+		return fmt.Errorf("drew %v steps without every octopus flashing at once", MaxSteps)
 	}
+	return nil
+}
 
-	countZeros := func(coordinate geometry.Coordinate, value int) int {
-		if value == 0 {
-			zeroCount++
-		}
+// WatchSimulation redraws the octopuses in the terminal after every step, for the same steps RenderSimulation draws,
+// taking pause, step and quit commands from controls.
+func WatchSimulation(filename string, framesPerSecond float64, paused bool, controls io.Reader) {
+	grid := collections.ParseDigitGrid(loadPuzzleInput(filename))
 
-		return value
+	palette := energyPalette()
+	terminal := render.NewTerminal[int](os.Stdout, framesPerSecond, func(energyLevel int) color.Color { return palette[energyLevel] })
+	terminal.Controls(controls)
+	if paused {
+		terminal.Pause()
 	}
 
-	for j := 0; j < 500; j++ {
+	if simulate(&grid, func() bool { return terminal.Show(&grid) }) == gaveUp {
+		log.Warn().Str("filename", filename).Int("steps", MaxSteps).Msg("octopuses never all flashed at once")
+	}
+}
 
-		// stage 1
-		matrix.VisitEach(incrementValue)
+// energyPalette makes flashing octopuses white; the rest glow brighter the more energy they hold.
+func energyPalette() color.Palette {
+	return append(color.Palette{color.White}, render.Gradient(color.RGBA{A: 255}, color.RGBA{G: 160, B: 200, A: 255}, FlashPoint)...)
+}
 
-		// stage 2
-		for {
-			matrix.VisitEach(recordFlashed)
-			matrix.ForEachAdjacentIn(flashedDuringStep, incrementValue)
-			if len(flashedDuringStep) == 0 {
-				break
-			}
-			flashedDuringStep = []geometry.Coordinate{}
-		}
+// simulation says why simulate stopped.
+type simulation int
 
-		// stage 3
-		for k := range flashedMap {
-			flashedDuringStep = append(flashedDuringStep, k)
-		}
-		flashedMap = make(map[geometry.Coordinate]int)
-		matrix.ForEachIn(flashedDuringStep, setToZero)
+const (
+	synchronised simulation = iota
+	stopped
+	gaveUp
+)
 
-		matrix.VisitEach(countZeros)
+// simulate shows the grid before the first step and after each one until a few steps past the first in which every
+// octopus flashes. It stops early if show returns false, and gives up after MaxSteps steps without every octopus
+// flashing at once.
+func simulate(grid *collections.Grid[int], show func() bool) simulation {
+	if !show() {
+		return stopped
+	}
 
-		if zeroCount == matrix.Size() {
-			return j + 1
+	afterwards := 0
+	for step := 1; afterwards < 5; step++ {
+		if afterwards == 0 && step > MaxSteps {
+			return gaveUp
 		}
 
-		zeroCount = 0
-		flashedDuringStep = []geometry.Coordinate{}
+		if Step(grid) == grid.Size() || afterwards > 0 {
+			afterwards++
+		}
+		if !show() {
+			return stopped
+		}
 	}
 
-	return flashedCount
+	return synchronised
 }
 
 /*
@@ -149,6 +180,22 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	renderTo := flag.String("render", "", "draw the puzzle input's octopuses flashing to this GIF (or, ending in .png, the final step)")
+	watch := flag.Bool("watch", false, "watch the puzzle input's octopuses flash in the terminal before solving")
+	framesPerSecond := flag.Float64("fps", 10, "frames per second to watch at")
+	paused := flag.Bool("paused", false, "start watching paused; enter p to carry on, s to step and q to quit")
+	flag.Parse()
+
+	if *watch {
+		WatchSimulation("puzzle-input.dat", *framesPerSecond, *paused, os.Stdin)
+	}
+
+	if len(*renderTo) > 0 {
+		if err := RenderSimulation("puzzle-input.dat", *renderTo); err != nil {
+			log.Error().Err(err).Str("filename", *renderTo).Msg("rendering")
+		}
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -180,7 +227,7 @@ func main() {
 		Int64("part-one-duration", partOneResult.duration).
 		Int("part-two-answer", partTwoResult.answer).
 		Int64("part-two-duration", partTwoResult.duration).
-		Msg("day 04")
+		Msg("day 11")
 }
 
 /*
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// These octopuses never all flash at once.
+var neverSynchronised = []string{"373", "193", "312"}
+
+func TestFindSolutionForInput2_GivesUp(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input.dat")
+	_ = os.WriteFile(filename, []byte(strings.Join(neverSynchronised, "\n")), 0644)
+
+	if step := FindSolutionForInput2(filename); step != 0 {
+		t.Logf("Expected to give up with 0, got %v", step)
+		t.Fail()
+	}
+}
+
+func TestSimulate_GivesUp(t *testing.T) {
+	grid := collections.ParseDigitGrid(neverSynchronised)
+
+	frames := 0
+	if simulate(&grid, func() bool { frames++; return true }) != gaveUp || frames != MaxSteps+1 {
+		t.Logf("Expected to give up after %v steps, got %v frames", MaxSteps, frames)
+		t.Fail()
+	}
+}
+
+func TestSimulate_StopsAfterSynchronising(t *testing.T) {
+	grid := collections.ParseDigitGrid(loadPuzzleInput("example-input.dat"))
+
+	frames := 0
+	if simulate(&grid, func() bool { frames++; return true }) != synchronised || frames != 1+195+4 {
+		t.Logf("Expected the first frame, 195 steps and 4 more, got %v frames", frames)
+		t.Fail()
+	}
+}
+
+func TestSimulate_Stopped(t *testing.T) {
+	grid := collections.ParseDigitGrid(neverSynchronised)
+
+	frames := 0
+	if simulate(&grid, func() bool { frames++; return frames < 3 }) != stopped || frames != 3 {
+		t.Logf("Expected to stop at the third frame, got %v frames", frames)
+		t.Fail()
+	}
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/graph"
+	"advent-of-code-2021/utility/render"
+	"flag"
 	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -40,59 +44,113 @@ func (vi *VertexInfo) IsStart() bool {
 	return vi.label == "start"
 }
 
-type AdjacencyList struct {
-	adjacent map[VertexInfo][]VertexInfo
-}
+var (
+	Start = NewVertexInfo("start")
+	End   = NewVertexInfo("end")
+)
 
-func NewAdjacencyList(puzzleInput []string) AdjacencyList {
-	adjacencyList := AdjacencyList{
-		adjacent: make(map[VertexInfo][]VertexInfo),
-	}
+type CaveSystem struct {
+	caves graph.Graph[VertexInfo]
+}
 
-	for _, line := range puzzleInput {
-		parts := strings.Split(line, "-")
-		from := NewVertexInfo(parts[0])
-		to := NewVertexInfo(parts[1])
-		adjacencyList.adjacent[from] = append(adjacencyList.adjacent[from], to)
-		adjacencyList.adjacent[to] = append(adjacencyList.adjacent[to], from)
+func NewCaveSystem(puzzleInput []string) CaveSystem {
+	caves, err := graph.FromEdgeList(puzzleInput, false, func(line string) (graph.Edge[VertexInfo], error) {
+		edge, err := graph.ParseEdge(line, "-")
+		return graph.Edge[VertexInfo]{From: NewVertexInfo(edge.From), To: NewVertexInfo(edge.To), Weight: edge.Weight}, err
+	})
+	if err != nil {
+		panic(fmt.Sprintf("parse cave system: %v", err))
 	}
 
-	return adjacencyList
+	return CaveSystem{caves: caves}
 }
 
-func (al *AdjacencyList) Traverse(
+func (cs *CaveSystem) Traverse(
 	handlePathFound func(path []VertexInfo),
-	navigateNext func(destination VertexInfo, visited map[VertexInfo]int, exceededSmallCaveVisits bool) bool) int {
-
-	multipleVisits := false
-
-	var traverseGraph func(current VertexInfo, path []VertexInfo, visited map[VertexInfo]int, pathCount int, exceededSmallCaveVisits bool) int
-	traverseGraph = func(current VertexInfo, path []VertexInfo, visited map[VertexInfo]int, pathCount int, exceededSmallCaveVisits bool) int {
-		path = append(path, current)
-		for _, destination := range al.adjacent[current] {
-			if destination.IsEnd() {
-				//path = append(path, destination)
-				pathCount++
-				handlePathFound(path)
-				continue
+	navigateNext func(destination VertexInfo, visits map[VertexInfo]int) bool) int {
+
+	return cs.caves.EnumeratePaths(Start, End, navigateNext, handlePathFound)
+}
+
+// DrawSVG draws the caves with big caves shaded and start and end picked out.
+func (cs *CaveSystem) DrawSVG() render.SVG {
+	return cs.caves.ToSVG(graph.ExportOptions[VertexInfo]{
+		Label: func(cave VertexInfo) string { return cave.label },
+		Style: func(cave VertexInfo) graph.VertexStyle {
+			switch {
+			case cave.IsStart() || cave.IsEnd():
+				return graph.VertexStyle{FillColor: "#9fd49f"}
+			case cave.IsBig():
+				return graph.VertexStyle{FillColor: "#dddddd"}
+			default:
+				return graph.VertexStyle{}
 			}
+		},
+	}, 16)
+}
+
+type pathCountState struct {
+	cave   VertexInfo
+	visits string
+}
+
+// CountPaths
+// Counts the same paths as Traverse with policy.CanEnter without ever building one.
+// The count from any cave depends only on how often each cave the policy limits is already on the path,
+// so those counts, one byte per cave, are all the memo is keyed on. Unlimited big caves are left out.
+func (cs *CaveSystem) CountPaths(policy VisitPolicy) int {
+	limited := make(map[VertexInfo]int)
+	for _, cave := range cs.caves.Vertices() {
+		if policy.Limits(cave) {
+			limited[cave] = len(limited)
+		}
+	}
+
+	visits := map[VertexInfo]int{Start: 1}
+	counts := make([]byte, len(limited))
+	if index, found := limited[Start]; found {
+		counts[index] = 1
+	}
 
-			multipleVisits = multipleVisits || (current.IsSmall() && visited[current] >= 1)
+	memo := make(map[pathCountState]int)
 
-			visited[current]++
+	var countFrom func(cave VertexInfo) int
+	countFrom = func(cave VertexInfo) int {
+		state := pathCountState{cave: cave, visits: string(counts)}
+		if count, found := memo[state]; found {
+			return count
+		}
 
-			if navigateNext(destination, visited, exceededSmallCaveVisits) {
-				pathCount = traverseGraph(destination, path, visited, pathCount, multipleVisits)
+		count := 0
+		for _, destination := range cs.caves.Neighbours(cave) {
+			if !policy.CanEnter(destination, visits) {
+				continue
 			}
 
-			visited[current]--
+			if destination == End {
+				count++
+				continue
+			}
+
+			index, tracked := limited[destination]
+			visits[destination]++
+			if tracked {
+				counts[index]++
+			}
+
+			count += countFrom(destination)
+
+			visits[destination]--
+			if tracked {
+				counts[index]--
+			}
 		}
 
-		return pathCount
+		memo[state] = count
+		return count
 	}
 
-	startingPathCount := 0
-	return traverseGraph(VertexInfo{label: "start"}, []VertexInfo{}, make(map[VertexInfo]int), startingPathCount, multipleVisits)
+	return countFrom(Start)
 }
 
 func PrintPaths(paths [][]VertexInfo) {
@@ -114,24 +172,156 @@ func PrintPath(path []VertexInfo) {
 	}
 }
 
-func VisitSmallCavesOnlyOnce(destination VertexInfo, visited map[VertexInfo]int, _ bool) bool {
-	count := visited[destination]
-	return count == 0 || destination.IsBig()
+// VisitPolicy
+// Decides which caves a path may step into next, written as comma separated rules, e.g. "small=1,revisit=2,forbid=dc|kj".
+//
+//	small=N     each small cave may be on a path at most N times (default 1)
+//	revisit=M   any one small cave may instead be on it up to M times (default, no revisits)
+//	big=N       each big cave may be on a path at most N times (default 0, unlimited)
+//	start=N     start may be on a path at most N times, counting the first (default 1)
+//	forbid=a|b  caves that may never be entered
+//
+// A path always finishes the first time it reaches end, and start and end are not subject to the small cave rules.
+// No limit may be more than MaxVisitLimit.
+type VisitPolicy struct {
+	definition string
+
+	smallLimit   int
+	revisitLimit int
+	bigLimit     int
+	startLimit   int
+	forbidden    map[VertexInfo]bool
 }
 
-func ExtendedSearch(destination VertexInfo, visited map[VertexInfo]int, multipleVisits bool) bool {
-	return (destination.IsBig() ||
-		(visited[destination] < 2 && !multipleVisits) ||
-		visited[destination] == 0) && !destination.IsStart()
+const (
+	PartOnePolicy = "small=1"
+	PartTwoPolicy = "small=1,revisit=2"
+)
+
+// MaxVisitLimit is as many visits as CountPaths can record, one byte per cave.
+const MaxVisitLimit = 255
+
+func ParseVisitPolicy(definition string) (VisitPolicy, error) {
+	policy := VisitPolicy{
+		definition: definition,
+		smallLimit: 1,
+		startLimit: 1,
+		forbidden:  make(map[VertexInfo]bool),
+	}
+
+	for _, rule := range strings.Split(definition, ",") {
+		rule = strings.TrimSpace(rule)
+		if len(rule) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(rule, "=", 2)
+		if len(parts) != 2 {
+			return policy, fmt.Errorf("rule '%v' should look like name=value", rule)
+		}
+
+		name := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		if name == "forbid" {
+			for _, label := range strings.Split(value, "|") {
+				policy.forbidden[NewVertexInfo(strings.TrimSpace(label))] = true
+			}
+			continue
+		}
+
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return policy, fmt.Errorf("rule '%v' needs a whole number of visits", rule)
+		}
+		if limit > MaxVisitLimit {
+			return policy, fmt.Errorf("rule '%v' allows more than %v visits", rule, MaxVisitLimit)
+		}
+
+		switch name {
+		case "small":
+			policy.smallLimit = limit
+		case "revisit":
+			policy.revisitLimit = limit
+		case "big":
+			policy.bigLimit = limit
+		case "start":
+			policy.startLimit = limit
+		default:
+			return policy, fmt.Errorf("unknown rule '%v'", name)
+		}
+	}
+
+	if policy.revisitLimit > 0 && policy.revisitLimit < policy.smallLimit {
+		return policy, fmt.Errorf("revisit=%v allows fewer visits than small=%v", policy.revisitLimit, policy.smallLimit)
+	}
+
+	if policy.startLimit < 1 {
+		return policy, fmt.Errorf("start=%v leaves nowhere to begin", policy.startLimit)
+	}
+
+	return policy, nil
+}
+
+func MustParseVisitPolicy(definition string) VisitPolicy {
+	policy, err := ParseVisitPolicy(definition)
+	if err != nil {
+		panic(fmt.Sprintf("parse visit policy '%v': %v", definition, err))
+	}
+	return policy
+}
+
+func (vp VisitPolicy) CanEnter(destination VertexInfo, visits map[VertexInfo]int) bool {
+	if vp.forbidden[destination] {
+		return false
+	}
+
+	count := visits[destination]
+
+	switch {
+	case destination.IsStart():
+		return count < vp.startLimit
+	case destination.IsEnd():
+		return true
+	case destination.IsBig():
+		return vp.bigLimit == 0 || count < vp.bigLimit
+	case count < vp.smallLimit:
+		return true
+	case count < vp.revisitLimit:
+		return !vp.anotherSmallCaveRevisited(destination, visits)
+	default:
+		return false
+	}
+}
+
+// Limits reports whether the policy ever refuses entry to cave because of how often it has been visited.
+func (vp VisitPolicy) Limits(cave VertexInfo) bool {
+	if cave.IsEnd() {
+		return false
+	}
+	return !cave.IsBig() || vp.bigLimit > 0
+}
+
+func (vp VisitPolicy) String() string {
+	return vp.definition
+}
+
+func (vp VisitPolicy) anotherSmallCaveRevisited(destination VertexInfo, visits map[VertexInfo]int) bool {
+	for cave, count := range visits {
+		if cave != destination && cave.IsSmall() && !cave.IsStart() && !cave.IsEnd() && count > vp.smallLimit {
+			return true
+		}
+	}
+	return false
 }
 
-func FindSolutionForInput(filename string, navigateNext func(destination VertexInfo, visited map[VertexInfo]int, multipleVisitsToSmall bool) bool) int {
+func FindSolutionForInput(filename string, navigateNext func(destination VertexInfo, visits map[VertexInfo]int) bool) int {
 	var paths [][]VertexInfo
-	trackPaths := func(path []VertexInfo) { paths = append(paths, path) }
+	trackPaths := func(path []VertexInfo) { paths = append(paths, append([]VertexInfo{}, path...)) }
 	puzzleInput := loadPuzzleInput(filename)
-	adjacencyList := NewAdjacencyList(puzzleInput)
+	caveSystem := NewCaveSystem(puzzleInput)
 
-	solution := adjacencyList.Traverse(trackPaths, navigateNext)
+	solution := caveSystem.Traverse(trackPaths, navigateNext)
 	//PrintPaths(paths)
 
 	//toString := func(infos []VertexInfo) string {
@@ -139,7 +329,6 @@ func FindSolutionForInput(filename string, navigateNext func(destination VertexI
 	//	for _, info := range infos {
 	//		labels = append(labels, info.label)
 	//	}
-	//	labels = append(labels, "end")
 	//	return strings.Join(labels, ",")
 	//}
 	//
@@ -158,6 +347,17 @@ func FindSolutionForInput(filename string, navigateNext func(destination VertexI
 	return solution
 }
 
+func CountSolutionForInput(filename string, policy VisitPolicy) int {
+	puzzleInput := loadPuzzleInput(filename)
+	caveSystem := NewCaveSystem(puzzleInput)
+
+	return caveSystem.CountPaths(policy)
+}
+
+func EnumerateSolutionForInput(filename string, policy VisitPolicy) int {
+	return FindSolutionForInput(filename, policy.CanEnter)
+}
+
 /*
 	Main
 */
@@ -170,6 +370,28 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	countOnly := flag.Bool("count", false, "count paths with memoization instead of enumerating every one of them")
+	partOneDefinition := flag.String("part-one-policy", PartOnePolicy, "visit policy for part one, e.g. small=1,revisit=2,big=0,start=1,forbid=a|b")
+	partTwoDefinition := flag.String("part-two-policy", PartTwoPolicy, "visit policy for part two")
+	svgTo := flag.String("svg", "", "draw the puzzle input's caves to this SVG")
+	flag.Parse()
+
+	if len(*svgTo) > 0 {
+		caveSystem := NewCaveSystem(loadPuzzleInput("puzzle-input.dat"))
+		svg := caveSystem.DrawSVG()
+		if err := svg.Save(*svgTo); err != nil {
+			log.Error().Err(err).Str("filename", *svgTo).Msg("drawing")
+		}
+	}
+
+	partOnePolicy := MustParseVisitPolicy(*partOneDefinition)
+	partTwoPolicy := MustParseVisitPolicy(*partTwoDefinition)
+
+	solve := EnumerateSolutionForInput
+	if *countOnly {
+		solve = CountSolutionForInput
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -179,10 +401,10 @@ func main() {
 	partOneChannel := make(chan Result)
 	partTwoChannel := make(chan Result)
 
-	go doExampleOne(exampleChannelOne, &waitGroup)
-	go doExampleTwo(exampleChannelTwo, &waitGroup)
-	go doPartOne(partOneChannel, &waitGroup)
-	go doPartTwo(partTwoChannel, &waitGroup)
+	go doExampleOne(exampleChannelOne, &waitGroup, solve, partOnePolicy)
+	go doExampleTwo(exampleChannelTwo, &waitGroup, solve, partTwoPolicy)
+	go doPartOne(partOneChannel, &waitGroup, solve, partOnePolicy)
+	go doPartTwo(partTwoChannel, &waitGroup, solve, partTwoPolicy)
 
 	exampleResultOne := <-exampleChannelOne
 	exampleResultTwo := <-exampleChannelTwo
@@ -208,40 +430,40 @@ func main() {
 	Executors
 */
 
-func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup) {
+func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup, solve func(filename string, policy VisitPolicy) int, policy VisitPolicy) {
 	start := time.Now()
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat", VisitSmallCavesOnlyOnce),
+		answer:   solve("example-input.dat", policy),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup, solve func(filename string, policy VisitPolicy) int, policy VisitPolicy) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat", ExtendedSearch),
+		answer:   solve("example-input.dat", policy),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartOne(channel chan Result, waitGroup *sync.WaitGroup, solve func(filename string, policy VisitPolicy) int, policy VisitPolicy) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat", VisitSmallCavesOnlyOnce),
+		answer:   solve("puzzle-input.dat", policy),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup, solve func(filename string, policy VisitPolicy) int, policy VisitPolicy) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat", ExtendedSearch),
+		answer:   solve("puzzle-input.dat", policy),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
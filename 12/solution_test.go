@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+var examples = []struct {
+	name     string
+	input    []string
+	expected [2]int
+}{
+	{
+		name:     "small",
+		input:    []string{"start-A", "start-b", "A-c", "A-b", "b-d", "A-end", "b-end"},
+		expected: [2]int{10, 36},
+	},
+	{
+		name: "medium",
+		input: []string{
+			"dc-end", "HN-start", "start-kj", "dc-start", "dc-HN",
+			"LN-dc", "HN-end", "kj-sa", "kj-HN", "kj-dc",
+		},
+		expected: [2]int{19, 103},
+	},
+	{
+		name:     "large",
+		input:    loadPuzzleInput("example-input.dat"),
+		expected: [2]int{226, 3509},
+	},
+}
+
+func TestCountPathsMatchesTraverse(t *testing.T) {
+	ignorePath := func(path []VertexInfo) {}
+
+	for _, example := range examples {
+		caveSystem := NewCaveSystem(example.input)
+
+		for part, definition := range []string{PartOnePolicy, PartTwoPolicy} {
+			policy := MustParseVisitPolicy(definition)
+
+			enumerated := caveSystem.Traverse(ignorePath, policy.CanEnter)
+			counted := caveSystem.CountPaths(policy)
+
+			if enumerated != example.expected[part] || counted != example.expected[part] {
+				t.Logf("%v example, part %v: expected %v, Traverse found %v and CountPaths %v",
+					example.name, part+1, example.expected[part], enumerated, counted)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestVisitPolicyVariantsAgree(t *testing.T) {
+	ignorePath := func(path []VertexInfo) {}
+
+	for _, definition := range []string{"small=1,forbid=b", "small=1,revisit=3", "small=2", "small=1,big=2", "start=2"} {
+		policy := MustParseVisitPolicy(definition)
+
+		for _, example := range examples[:2] {
+			caveSystem := NewCaveSystem(example.input)
+
+			enumerated := caveSystem.Traverse(ignorePath, policy.CanEnter)
+			counted := caveSystem.CountPaths(policy)
+
+			if enumerated != counted {
+				t.Logf("%v example, policy %v: Traverse found %v paths, CountPaths %v", example.name, policy, enumerated, counted)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestVisitPolicy_ForbiddenCave(t *testing.T) {
+	caveSystem := NewCaveSystem(examples[0].input)
+	policy := MustParseVisitPolicy("small=1,forbid=b")
+
+	// with b forbidden only start,A,end and start,A,c,A,end remain
+	if count := caveSystem.CountPaths(policy); count != 2 {
+		t.Logf("Expected 2, got %v", count)
+		t.Fail()
+	}
+}
+
+func TestParseVisitPolicy_Invalid(t *testing.T) {
+	for _, definition := range []string{"small", "small=x", "often=2", "small=2,revisit=1", "start=0", "big=-1", "small=256", "revisit=300"} {
+		if _, err := ParseVisitPolicy(definition); err == nil {
+			t.Logf("Expected an error for '%v'", definition)
+			t.Fail()
+		}
+	}
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/collections"
 	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/ocr"
+	"advent-of-code-2021/utility/render"
+	"flag"
 	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"image/color"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,13 +28,14 @@ type Fold struct {
 }
 
 type Puzzle struct {
-	coordinates []map[geometry.Coordinate]int
+	coordinates []collections.SparseGrid[int]
 	folds       []Fold
 }
 
 func NewPuzzle(data []string) Puzzle {
+	increment := func(count int, _ bool) int { return count + 1 }
 	inFoldDefs := false
-	initialCoordinates := make(map[geometry.Coordinate]int)
+	initialCoordinates := collections.NewSparseGrid[int]()
 	var folds []Fold
 	for _, line := range data {
 		if len(line) == 0 {
@@ -50,11 +57,11 @@ func NewPuzzle(data []string) Puzzle {
 			points := strings.Split(line, ",")
 			abscissa, _ := strconv.Atoi(points[0])
 			ordinate, _ := strconv.Atoi(points[1])
-			initialCoordinates[geometry.NewCoordinate(abscissa, ordinate)]++
+			initialCoordinates.Update(geometry.NewCoordinate(abscissa, ordinate), increment)
 		}
 	}
 
-	var coordinates []map[geometry.Coordinate]int
+	var coordinates []collections.SparseGrid[int]
 	coordinates = append(coordinates, initialCoordinates)
 
 	return Puzzle{
@@ -63,112 +70,261 @@ func NewPuzzle(data []string) Puzzle {
 	}
 }
 
-func (p *Puzzle) FoldAt(axis geometry.Axis, index int) int {
-	nextCoordinates := make(map[geometry.Coordinate]int)
-	for coordinate := range p.coordinates[p.LastFold()] {
-		if axis == geometry.Vertical {
-			if coordinate.Y > index {
-				updatedCoordinate := geometry.NewCoordinate(coordinate.X, (index*2)-coordinate.Y)
-				nextCoordinates[updatedCoordinate]++
-			} else {
-				nextCoordinates[coordinate]++
-			}
-		} else {
-			if coordinate.X > index {
-				updatedCoordinate := geometry.NewCoordinate((index*2)-coordinate.X, coordinate.Y)
-				nextCoordinates[updatedCoordinate]++
-			} else {
-				nextCoordinates[coordinate]++
-			}
-		}
+// FoldRegion is a column or row of the paper that every fold along its axis moves as one piece, so a single transform
+// takes it to where it ends up.
+type FoldRegion struct {
+	source    geometry.Rect
+	transform geometry.Transform
+}
+
+// Reflection maps the far side of the fold back over the near side.
+func (f Fold) Reflection() geometry.Transform {
+	if f.axis == geometry.Horizontal {
+		return geometry.NewReflectionX(f.index)
 	}
+	return geometry.NewReflectionY(f.index)
+}
 
-	p.coordinates = append(p.coordinates, nextCoordinates)
+func (f Fold) String() string {
+	if f.axis == geometry.Horizontal {
+		return fmt.Sprintf("x=%v", f.index)
+	}
+	return fmt.Sprintf("y=%v", f.index)
+}
 
-	return len(nextCoordinates)
+// Split divides region into the part up to and including the fold line, which stays put, and the part beyond it.
+func (f Fold) Split(region geometry.Rect) (geometry.Rect, geometry.Rect) {
+	if f.axis == geometry.Horizontal {
+		return region.Intersect(geometry.NewRect(region.Min, geometry.NewCoordinate(f.index, region.Max.Y))),
+			region.Intersect(geometry.NewRect(geometry.NewCoordinate(f.index+1, region.Min.Y), region.Max))
+	}
+	return region.Intersect(geometry.NewRect(region.Min, geometry.NewCoordinate(region.Max.X, f.index))),
+		region.Intersect(geometry.NewRect(geometry.NewCoordinate(region.Min.X, f.index+1), region.Max))
 }
 
-func (p *Puzzle) LastFold() int {
-	return len(p.coordinates) - 1
+// FoldPlan is where ComposeFolds says each part of the paper ends up.
+// Folds along x only ever split the paper into columns, and folds along y into rows, so each part is a column paired
+// with a row and is moved by the column's transform and then the row's. Both are kept sorted, so finding a dot's part
+// is a binary search on each axis.
+type FoldPlan struct {
+	columns []FoldRegion
+	rows    []FoldRegion
 }
 
-func (p *Puzzle) Height() int {
-	index := p.LastFold()
-	height := 0
-	for coordinate := range p.coordinates[index] {
-		if coordinate.Y > height {
-			height = coordinate.Y
+// ComposeFolds works out, without moving any dots, which transform each part of bounds ends up under.
+func ComposeFolds(bounds geometry.Rect, folds []Fold) FoldPlan {
+	var columnFolds, rowFolds []Fold
+	for _, fold := range folds {
+		if fold.axis == geometry.Horizontal {
+			columnFolds = append(columnFolds, fold)
+		} else {
+			rowFolds = append(rowFolds, fold)
 		}
 	}
-	return height
+
+	columns := composeBands(bounds, columnFolds)
+	sort.Slice(columns, func(i, j int) bool { return columns[i].source.Min.X < columns[j].source.Min.X })
+
+	rows := composeBands(bounds, rowFolds)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].source.Min.Y < rows[j].source.Min.Y })
+
+	return FoldPlan{columns: columns, rows: rows}
 }
 
-func (p *Puzzle) Width() int {
-	index := p.LastFold()
-	width := 0
-	for coordinate := range p.coordinates[index] {
-		if coordinate.X > width {
-			width = coordinate.X
+// composeBands follows folds all along one axis. Each fold splits any band whose current image straddles the fold line,
+// and adds the reflection to the far part.
+func composeBands(bounds geometry.Rect, folds []Fold) []FoldRegion {
+	bands := []FoldRegion{{source: bounds, transform: geometry.IdentityTransform()}}
+
+	for _, fold := range folds {
+		var next []FoldRegion
+		for _, band := range bands {
+			kept, beyond := fold.Split(band.transform.ApplyRect(band.source))
+			toSource := band.transform.Inverse()
+
+			if !kept.IsEmpty() {
+				next = append(next, FoldRegion{source: toSource.ApplyRect(kept), transform: band.transform})
+			}
+			if !beyond.IsEmpty() {
+				next = append(next, FoldRegion{source: toSource.ApplyRect(beyond), transform: band.transform.Then(fold.Reflection())})
+			}
 		}
+		bands = next
+	}
+
+	return bands
+}
+
+// Apply moves coordinate to where the folds take it, or reports false if it lies outside the paper.
+func (p FoldPlan) Apply(coordinate geometry.Coordinate) (geometry.Coordinate, bool) {
+	column := sort.Search(len(p.columns), func(index int) bool { return p.columns[index].source.Max.X >= coordinate.X })
+	row := sort.Search(len(p.rows), func(index int) bool { return p.rows[index].source.Max.Y >= coordinate.Y })
+	if column == len(p.columns) || row == len(p.rows) ||
+		!p.columns[column].source.Contains(coordinate) || !p.rows[row].source.Contains(coordinate) {
+		return coordinate, false
 	}
-	return width
+
+	return p.rows[row].transform.Apply(p.columns[column].transform.Apply(coordinate)), true
+}
+
+// ApplyFolds makes every one of the folds, moving each dot once, and returns how many dots remain visible.
+func (p *Puzzle) ApplyFolds(folds []Fold) int {
+	dots := p.coordinates[p.LastFold()]
+	plan := ComposeFolds(dots.Bounds(), folds)
+
+	folded := collections.NewSparseGrid[int]()
+	dots.ForEach(func(coordinate geometry.Coordinate, count int) {
+		if moved, onPaper := plan.Apply(coordinate); onPaper {
+			folded.Update(moved, func(existing int, _ bool) int { return existing + count })
+		}
+	})
+
+	p.coordinates = append(p.coordinates, folded)
+
+	return folded.Len()
+}
+
+func (p *Puzzle) LastFold() int {
+	return len(p.coordinates) - 1
 }
 
 func (p *Puzzle) Print() {
-	width := p.Width()
-	height := p.Height()
-	index := p.LastFold()
-
-	for y := 0; y <= height; y++ {
-		for x := 0; x <= width; x++ {
-			if _, found := p.coordinates[index][geometry.NewCoordinate(x, y)]; found {
-				fmt.Print("#")
-			} else {
-				fmt.Print(".")
-			}
+	dots := p.coordinates[p.LastFold()]
+	fmt.Print(dots.RenderRegion(geometry.NewRect(geometry.NewCoordinate(0, 0), dots.Max()), func(_ int, found bool) rune {
+		if found {
+			return '#'
 		}
-		fmt.Println()
-	}
+		return '.'
+	}))
+}
+
+// Read recognises the letters the dots spell out.
+func (p *Puzzle) Read() (string, error) {
+	return ocr.RecognizeCoordinates(p.coordinates[p.LastFold()].Coordinates())
 }
 
-func partOne(puzzle Puzzle) int {
-	fold := puzzle.folds[0]
-	return puzzle.FoldAt(fold.axis, fold.index)
+func partOne(puzzle Puzzle) string {
+	return strconv.Itoa(puzzle.ApplyFolds(puzzle.folds[:1]))
 }
 
-//#..#...##.###..#..#.####.#..#.###...##.
-//#.#.....#.#..#.#.#..#....#..#.#..#.#..#
-//##......#.###..##...###..#..#.###..#...
-//#.#.....#.#..#.#.#..#....#..#.#..#.#.##
-//#.#..#..#.#..#.#.#..#....#..#.#..#.#..#
-//#..#..##..###..#..#.####..##..###...###
-func partTwo(puzzle Puzzle) int {
-	solution := 0
-	for _, fold := range puzzle.folds {
-		solution = puzzle.FoldAt(fold.axis, fold.index)
+// partTwo
+// The dots spell out the answer; when they do not, as in the example, they are printed and counted instead.
+func partTwo(puzzle Puzzle) string {
+	solution := puzzle.ApplyFolds(puzzle.folds)
+
+	letters, err := puzzle.Read()
+	if err != nil {
+		puzzle.Print()
+		return strconv.Itoa(solution)
 	}
-	puzzle.Print()
-	return solution
+
+	return letters
 }
 
-func FindSolutionForInput(filename string, operation func(puzzle Puzzle) int) int {
+func FindSolutionForInput(filename string, operation func(puzzle Puzzle) string) string {
 	puzzleInput := loadPuzzleInput(filename)
 	puzzle := NewPuzzle(puzzleInput)
 	return operation(puzzle)
 }
 
+// RenderFolds draws the paper before any folds and again after each one, always at its original size so the dots can
+// be seen gathering in the top left.
+func RenderFolds(filename string, output string) error {
+	puzzle := NewPuzzle(loadPuzzleInput(filename))
+	region := geometry.NewRect(geometry.NewCoordinate(0, 0), puzzle.coordinates[0].Max())
+
+	animation := render.NewAnimation(render.Monochrome, 50)
+	addFrame := func() error {
+		dots := puzzle.coordinates[puzzle.LastFold()]
+		return animation.AddFrame(render.DrawPoints(dots.Coordinates(), region, 1, color.White, color.Black))
+	}
+
+	if err := addFrame(); err != nil {
+		return err
+	}
+
+	for index := range puzzle.folds {
+		puzzle.ApplyFolds(puzzle.folds[index : index+1])
+		if err := addFrame(); err != nil {
+			return err
+		}
+	}
+
+	return animation.Save(output)
+}
+
+// DrawFolds draws the dots where they start out in grey, each fold line dashed, and the dots left after every fold in
+// black, optionally naming each fold line as the input does in the margin.
+func (p *Puzzle) DrawFolds(scale int, labels bool) render.SVG {
+	paper := geometry.NewRect(geometry.NewCoordinate(0, 0), p.coordinates[0].Max())
+	region := paper
+	if labels {
+		region = geometry.NewRect(geometry.NewCoordinate(-3, -2), paper.Max)
+	}
+	svg := render.NewSVG(region, scale)
+
+	dot := func(coordinate geometry.Coordinate, fill string) {
+		svg.Rect(geometry.NewRect(coordinate, coordinate), render.Style{Fill: fill})
+	}
+
+	for _, coordinate := range p.coordinates[0].Coordinates() {
+		dot(coordinate, "#cccccc")
+	}
+
+	foldLine := render.Style{Stroke: "#1f77b4", StrokeWidth: 0.2, Dashed: true}
+	for _, fold := range p.folds {
+		if fold.axis == geometry.Horizontal {
+			svg.Line(geometry.NewCoordinate(fold.index, 0), geometry.NewCoordinate(fold.index, paper.Max.Y), foldLine)
+			if labels {
+				svg.Text(geometry.NewCoordinate(fold.index, -1), fold.String(), render.Style{Fill: "#1f77b4", FontSize: 1})
+			}
+		} else {
+			svg.Line(geometry.NewCoordinate(0, fold.index), geometry.NewCoordinate(paper.Max.X, fold.index), foldLine)
+			if labels {
+				svg.Text(geometry.NewCoordinate(-2, fold.index), fold.String(), render.Style{Fill: "#1f77b4", FontSize: 1})
+			}
+		}
+	}
+
+	p.ApplyFolds(p.folds)
+	for _, coordinate := range p.coordinates[p.LastFold()].Coordinates() {
+		dot(coordinate, "#000000")
+	}
+
+	return svg
+}
+
 /*
 	Main
 */
 
 type Result struct {
-	answer   int
+	answer   string
 	duration int64
 }
 
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	renderTo := flag.String("render", "", "draw the puzzle input's paper after each fold to this GIF (or, ending in .png, the last fold)")
+	svgTo := flag.String("svg", "", "draw the puzzle input's dots before and after folding, and the folds, to this SVG")
+	labels := flag.Bool("labels", false, "name each fold line in the SVG")
+	flag.Parse()
+
+	if len(*renderTo) > 0 {
+		if err := RenderFolds("puzzle-input.dat", *renderTo); err != nil {
+			log.Error().Err(err).Str("filename", *renderTo).Msg("rendering")
+		}
+	}
+
+	if len(*svgTo) > 0 {
+		puzzle := NewPuzzle(loadPuzzleInput("puzzle-input.dat"))
+		svg := puzzle.DrawFolds(2, *labels)
+		if err := svg.Save(*svgTo); err != nil {
+			log.Error().Err(err).Str("filename", *svgTo).Msg("drawing")
+		}
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -192,13 +348,13 @@ func main() {
 
 	log.
 		Info().
-		Int("example-one-answer", exampleResultOne.answer).
+		Str("example-one-answer", exampleResultOne.answer).
 		Int64("example-one-duration", exampleResultOne.duration).
-		Int("example-two-answer", exampleResultTwo.answer).
+		Str("example-two-answer", exampleResultTwo.answer).
 		Int64("example-two-duration", exampleResultTwo.duration).
-		Int("part-one-answer", partOneResult.answer).
+		Str("part-one-answer", partOneResult.answer).
 		Int64("part-one-duration", partOneResult.duration).
-		Int("part-two-answer", partTwoResult.answer).
+		Str("part-two-answer", partTwoResult.answer).
 		Int64("part-two-duration", partTwoResult.duration).
 		Msg("day 04")
 }
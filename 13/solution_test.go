@@ -0,0 +1,64 @@
+package main
+
+import "testing"
+
+func TestFindSolutionForInput(t *testing.T) {
+	for _, testCase := range []struct {
+		filename  string
+		operation func(puzzle Puzzle) string
+		expected  string
+	}{
+		{"example-input.dat", partOne, "17"},
+		{"puzzle-input.dat", partOne, "788"},
+		{"puzzle-input.dat", partTwo, "KJBKEUBG"},
+	} {
+		if actual := FindSolutionForInput(testCase.filename, testCase.operation); actual != testCase.expected {
+			t.Logf("%v: expected %v, got %v", testCase.filename, testCase.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestPuzzle_DrawFolds(t *testing.T) {
+	puzzle := NewPuzzle([]string{"0,0", "2,1", "", "fold along x=1"})
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="60" height="40" viewBox="-3 -2 6 4">
+  <rect x="0" y="0" width="1" height="1" fill="#cccccc"/>
+  <rect x="2" y="1" width="1" height="1" fill="#cccccc"/>
+  <line x1="1.5" y1="0.5" x2="1.5" y2="1.5" fill="none" stroke="#1f77b4" stroke-width="0.2" stroke-dasharray="0.8 0.4"/>
+  <text x="1.5" y="-0.5" font-size="1" text-anchor="middle" dominant-baseline="central" fill="#1f77b4">x=1</text>
+  <rect x="0" y="0" width="1" height="1" fill="#000000"/>
+  <rect x="0" y="1" width="1" height="1" fill="#000000"/>
+</svg>
+`
+
+	svg := puzzle.DrawFolds(10, true)
+	if actual := svg.String(); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
+
+// Both fold the full puzzle input; folding in a single pass should never cost more than folding one step at a time.
+
+func BenchmarkApplyFolds(b *testing.B) {
+	puzzleInput := loadPuzzleInput("puzzle-input.dat")
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		puzzle := NewPuzzle(puzzleInput)
+		puzzle.ApplyFolds(puzzle.folds)
+	}
+}
+
+func BenchmarkApplyFolds_OneAtATime(b *testing.B) {
+	puzzleInput := loadPuzzleInput("puzzle-input.dat")
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		puzzle := NewPuzzle(puzzleInput)
+		for index := range puzzle.folds {
+			puzzle.ApplyFolds(puzzle.folds[index : index+1])
+		}
+	}
+}
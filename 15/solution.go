@@ -1,21 +1,79 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/graph"
+	"advent-of-code-2021/utility/render"
+	"flag"
+	"fmt"
 	"github.com/ciroque/advent-of-code-2020/support"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"strconv"
 	"sync"
 	"time"
 )
 
 /*
 	Solution implementation
+
+	https://adventofcode.com/2021/day/15
 */
 
-func FindSolutionForInput(filename string) int {
-	solution := 0
+func NewRiskMap(puzzleInput []string, tiles int) collections.TiledGrid[int] {
+	riskLevels := collections.ParseDigitGrid(puzzleInput)
+	return collections.NewTiledGrid[int](&riskLevels, tiles, tiles, WrapRiskLevel)
+}
+
+// WrapRiskLevel
+// Each tile to the right or down adds one to the risk level, with anything above 9 wrapping back around to 1.
+func WrapRiskLevel(riskLevel int, tile geometry.Coordinate) int {
+	return (riskLevel+tile.X+tile.Y-1)%9 + 1
+}
+
+func EnterRiskLevel(_ geometry.Coordinate, riskLevel int) int {
+	return riskLevel
+}
+
+func FindLowestRiskPath(riskMap collections.GridView[int]) graph.Path[geometry.Coordinate] {
+	start := geometry.NewCoordinate(0, 0)
+	goal := geometry.NewCoordinate(riskMap.Width()-1, riskMap.Height()-1)
+	isGoal := func(coordinate geometry.Coordinate) bool { return coordinate == goal }
+
+	path, _ := graph.AStar(start, isGoal, graph.GridNeighbours(riskMap, EnterRiskLevel), graph.ManhattanHeuristic(goal))
+
+	return path
+}
+
+func FindSolutionForInput(filename string, tiles int) int {
+	puzzleInput := loadPuzzleInput(filename)
+	riskMap := NewRiskMap(puzzleInput, tiles)
 
-	return solution
+	return FindLowestRiskPath(&riskMap).Cost
+}
+
+// DrawLowestRiskPath shades each position darker the riskier it is and draws the lowest risk path over the top,
+// optionally writing each risk level in its position.
+func DrawLowestRiskPath(riskMap collections.GridView[int], labels bool) render.SVG {
+	region := geometry.RectFromSize(geometry.NewCoordinate(0, 0), riskMap.Width(), riskMap.Height())
+	svg := render.NewSVG(region, 16)
+
+	region.ForEach(func(coordinate geometry.Coordinate) {
+		shade := 255 - riskMap.Get(coordinate)*20
+		fill := fmt.Sprintf("#%02x%02x%02x", shade, shade, shade)
+		svg.Rect(geometry.NewRect(coordinate, coordinate), render.Style{Fill: fill})
+	})
+
+	svg.Polyline(FindLowestRiskPath(riskMap).Vertices, render.Style{Stroke: "#d62728", StrokeWidth: 0.3})
+
+	if labels {
+		region.ForEach(func(coordinate geometry.Coordinate) {
+			svg.Text(coordinate, strconv.Itoa(riskMap.Get(coordinate)), render.Style{FontSize: 0.6})
+		})
+	}
+
+	return svg
 }
 
 /*
@@ -30,6 +88,18 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	svgTo := flag.String("svg", "", "draw the puzzle input's lowest risk path for part one to this SVG")
+	labels := flag.Bool("labels", false, "write each risk level in the SVG")
+	flag.Parse()
+
+	if len(*svgTo) > 0 {
+		riskMap := NewRiskMap(loadPuzzleInput("puzzle-input.dat"), 1)
+		svg := DrawLowestRiskPath(&riskMap, *labels)
+		if err := svg.Save(*svgTo); err != nil {
+			log.Error().Err(err).Str("filename", *svgTo).Msg("drawing")
+		}
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -72,7 +142,7 @@ func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat"),
+		answer:   FindSolutionForInput("example-input.dat", 1),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -82,7 +152,7 @@ func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("example-input.dat"),
+		answer:   FindSolutionForInput("example-input.dat", 5),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -92,7 +162,7 @@ func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat"),
+		answer:   FindSolutionForInput("puzzle-input.dat", 1),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -102,12 +172,12 @@ func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   FindSolutionForInput("puzzle-input.dat"),
+		answer:   FindSolutionForInput("puzzle-input.dat", 5),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
 }
 
-func loadPuzzleInput(filename string) string {
-	return support.ReadFile(filename)
+func loadPuzzleInput(filename string) []string {
+	return support.ReadFileIntoLines(filename)
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/graph"
+	"testing"
+)
+
+func TestShortestPathSearches(t *testing.T) {
+	for _, testCase := range []struct {
+		tiles    int
+		expected int
+	}{
+		{1, 40},
+		{5, 315},
+	} {
+		riskMap := NewRiskMap(loadPuzzleInput("example-input.dat"), testCase.tiles)
+		start := geometry.NewCoordinate(0, 0)
+		goal := geometry.NewCoordinate(riskMap.Width()-1, riskMap.Height()-1)
+		isGoal := func(coordinate geometry.Coordinate) bool { return coordinate == goal }
+		neighbours := graph.GridNeighbours[int](&riskMap, EnterRiskLevel)
+
+		dijkstra, found := graph.Dijkstra(start, isGoal, neighbours)
+		if !found || dijkstra.Cost != testCase.expected {
+			t.Logf("Dijkstra over %v tiles: expected %v, got %v", testCase.tiles, testCase.expected, dijkstra.Cost)
+			t.Fail()
+		}
+
+		aStar, found := graph.AStar(start, isGoal, neighbours, graph.ManhattanHeuristic(goal))
+		if !found || aStar.Cost != testCase.expected {
+			t.Logf("AStar over %v tiles: expected %v, got %v", testCase.tiles, testCase.expected, aStar.Cost)
+			t.Fail()
+		}
+
+		if actual := FindSolutionForInput("example-input.dat", testCase.tiles); actual != testCase.expected {
+			t.Logf("FindSolutionForInput over %v tiles: expected %v, got %v", testCase.tiles, testCase.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+// Both searches run over the full 500x500 tiled map for part two.
+
+func BenchmarkDijkstra(b *testing.B) {
+	riskMap := NewRiskMap(loadPuzzleInput("puzzle-input.dat"), 5)
+	goal := geometry.NewCoordinate(riskMap.Width()-1, riskMap.Height()-1)
+	isGoal := func(coordinate geometry.Coordinate) bool { return coordinate == goal }
+	neighbours := graph.GridNeighbours[int](&riskMap, EnterRiskLevel)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		graph.Dijkstra(geometry.NewCoordinate(0, 0), isGoal, neighbours)
+	}
+}
+
+func BenchmarkAStar(b *testing.B) {
+	riskMap := NewRiskMap(loadPuzzleInput("puzzle-input.dat"), 5)
+	goal := geometry.NewCoordinate(riskMap.Width()-1, riskMap.Height()-1)
+	isGoal := func(coordinate geometry.Coordinate) bool { return coordinate == goal }
+	neighbours := graph.GridNeighbours[int](&riskMap, EnterRiskLevel)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		graph.AStar(geometry.NewCoordinate(0, 0), isGoal, neighbours, graph.ManhattanHeuristic(goal))
+	}
+}
+
+func TestDrawLowestRiskPath(t *testing.T) {
+	riskMap := collections.ParseDigitGrid([]string{"19", "11"})
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="32" height="32" viewBox="0 0 2 2">
+  <rect x="0" y="0" width="1" height="1" fill="#ebebeb"/>
+  <rect x="1" y="0" width="1" height="1" fill="#4b4b4b"/>
+  <rect x="0" y="1" width="1" height="1" fill="#ebebeb"/>
+  <rect x="1" y="1" width="1" height="1" fill="#ebebeb"/>
+  <polyline points="0.5,0.5 0.5,1.5 1.5,1.5" fill="none" stroke="#d62728" stroke-width="0.3"/>
+  <text x="0.5" y="0.5" font-size="0.6" text-anchor="middle" dominant-baseline="central" fill="#000000">1</text>
+  <text x="1.5" y="0.5" font-size="0.6" text-anchor="middle" dominant-baseline="central" fill="#000000">9</text>
+  <text x="0.5" y="1.5" font-size="0.6" text-anchor="middle" dominant-baseline="central" fill="#000000">1</text>
+  <text x="1.5" y="1.5" font-size="0.6" text-anchor="middle" dominant-baseline="central" fill="#000000">1</text>
+</svg>
+`
+
+	svg := DrawLowestRiskPath(&riskMap, true)
+	if actual := svg.String(); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
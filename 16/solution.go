@@ -0,0 +1,357 @@
+package main
+
+import (
+	"advent-of-code-2021/utility/bits"
+	"errors"
+	"fmt"
+	"github.com/ciroque/advent-of-code-2020/support"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+	Solution implementation
+
+	https://adventofcode.com/2021/day/16
+*/
+
+const (
+	TypeSum         = 0
+	TypeProduct     = 1
+	TypeMinimum     = 2
+	TypeMaximum     = 3
+	TypeLiteral     = 4
+	TypeGreaterThan = 5
+	TypeLessThan    = 6
+	TypeEqualTo     = 7
+)
+
+const (
+	versionBits        = 3
+	typeIdBits         = 3
+	literalGroupBits   = 4
+	totalLengthBits    = 15
+	subPacketCountBits = 11
+)
+
+type Packet struct {
+	Version    int
+	TypeId     int
+	Value      int
+	SubPackets []Packet
+}
+
+func ParseTransmission(hex string) (Packet, error) {
+	reader, err := bits.NewHexBitReader(strings.TrimSpace(hex))
+	if err != nil {
+		return Packet{}, err
+	}
+
+	return DecodePacket(&reader)
+}
+
+// DecodePacket reads a single packet, and everything inside it, leaving any padding after it unread.
+func DecodePacket(reader *bits.BitReader) (Packet, error) {
+	var packet Packet
+	var err error
+
+	if packet.Version, err = reader.ReadInt(versionBits); err != nil {
+		return packet, fmt.Errorf("reading packet version: %w", err)
+	}
+	if packet.TypeId, err = reader.ReadInt(typeIdBits); err != nil {
+		return packet, fmt.Errorf("reading packet type: %w", err)
+	}
+
+	if packet.TypeId == TypeLiteral {
+		packet.Value, err = decodeLiteral(reader)
+		return packet, err
+	}
+
+	if packet.SubPackets, err = decodeSubPackets(reader); err != nil {
+		return packet, err
+	}
+
+	return packet, checkOperands(packet)
+}
+
+// checkOperands makes sure comparisons have exactly two sub-packets and every other operator at least one.
+func checkOperands(packet Packet) error {
+	switch packet.TypeId {
+	case TypeSum, TypeProduct, TypeMinimum, TypeMaximum:
+		if len(packet.SubPackets) == 0 {
+			return fmt.Errorf("operator type %v has no sub-packets", packet.TypeId)
+		}
+	case TypeGreaterThan, TypeLessThan, TypeEqualTo:
+		if len(packet.SubPackets) != 2 {
+			return fmt.Errorf("comparison type %v needs 2 sub-packets, got %v", packet.TypeId, len(packet.SubPackets))
+		}
+	default:
+		return fmt.Errorf("unknown packet type %v", packet.TypeId)
+	}
+	return nil
+}
+
+// decodeLiteral reads groups of four bits, each prefixed by a bit saying whether another group follows, failing on a
+// literal too large for an int.
+func decodeLiteral(reader *bits.BitReader) (int, error) {
+	value := 0
+	for more := true; more; {
+		var err error
+		if more, err = reader.ReadBool(); err != nil {
+			return 0, fmt.Errorf("reading literal: %w", err)
+		}
+
+		group, err := reader.ReadInt(literalGroupBits)
+		if err != nil {
+			return 0, fmt.Errorf("reading literal: %w", err)
+		}
+		if value > math.MaxInt>>literalGroupBits {
+			return 0, errors.New("literal is too large for an int")
+		}
+		value = value<<literalGroupBits | group
+	}
+	return value, nil
+}
+
+// decodeSubPackets reads an operator's sub-packets, using either the number of bits they take up or how many there
+// are, whichever the operator gives.
+func decodeSubPackets(reader *bits.BitReader) ([]Packet, error) {
+	countsPackets, err := reader.ReadBool()
+	if err != nil {
+		return nil, fmt.Errorf("reading length type: %w", err)
+	}
+
+	var subPackets []Packet
+
+	if countsPackets {
+		count, err := reader.ReadInt(subPacketCountBits)
+		if err != nil {
+			return nil, fmt.Errorf("reading sub-packet count: %w", err)
+		}
+
+		for index := 0; index < count; index++ {
+			subPacket, err := DecodePacket(reader)
+			if err != nil {
+				return nil, err
+			}
+			subPackets = append(subPackets, subPacket)
+		}
+
+		return subPackets, nil
+	}
+
+	length, err := reader.ReadInt(totalLengthBits)
+	if err != nil {
+		return nil, fmt.Errorf("reading sub-packet length: %w", err)
+	}
+
+	subReader, err := reader.SubReader(length)
+	if err != nil {
+		return nil, fmt.Errorf("reading %v bits of sub-packets: %w", length, err)
+	}
+
+	for subReader.Remaining() > 0 {
+		subPacket, err := DecodePacket(&subReader)
+		if err != nil {
+			return nil, err
+		}
+		subPackets = append(subPackets, subPacket)
+	}
+
+	return subPackets, nil
+}
+
+// Evaluate expects a packet as DecodePacket returns it, with its operands already checked.
+func (p Packet) Evaluate() int {
+	if p.TypeId == TypeLiteral {
+		return p.Value
+	}
+
+	values := make([]int, len(p.SubPackets))
+	for index, subPacket := range p.SubPackets {
+		values[index] = subPacket.Evaluate()
+	}
+
+	switch p.TypeId {
+	case TypeSum:
+		sum := 0
+		for _, value := range values {
+			sum += value
+		}
+		return sum
+	case TypeProduct:
+		product := 1
+		for _, value := range values {
+			product *= value
+		}
+		return product
+	case TypeMinimum:
+		minimum := values[0]
+		for _, value := range values[1:] {
+			if value < minimum {
+				minimum = value
+			}
+		}
+		return minimum
+	case TypeMaximum:
+		maximum := values[0]
+		for _, value := range values[1:] {
+			if value > maximum {
+				maximum = value
+			}
+		}
+		return maximum
+	case TypeGreaterThan:
+		return boolToInt(values[0] > values[1])
+	case TypeLessThan:
+		return boolToInt(values[0] < values[1])
+	default: // TypeEqualTo, as checkOperands allows no other
+		return boolToInt(values[0] == values[1])
+	}
+}
+
+func (p Packet) VersionSum() int {
+	sum := p.Version
+	for _, subPacket := range p.SubPackets {
+		sum += subPacket.VersionSum()
+	}
+	return sum
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// ErrNoInput is returned for an input file that is missing or empty, as puzzle-input.dat is until it is downloaded.
+var ErrNoInput = errors.New("no input")
+
+func FindSolutionForInput(filename string, solve func(packet Packet) int) (int, error) {
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		return 0, fmt.Errorf("%v: %w", filename, ErrNoInput)
+	}
+
+	packet, err := ParseTransmission(loadPuzzleInput(filename))
+	if err != nil {
+		return 0, fmt.Errorf("decoding %v: %w", filename, err)
+	}
+
+	return solve(packet), nil
+}
+
+/*
+	Main
+*/
+
+type Result struct {
+	answer   int
+	duration int64
+	err      error
+}
+
+func main() {
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+
+	waitCount := 4
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(waitCount)
+
+	exampleChannelOne := make(chan Result)
+	exampleChannelTwo := make(chan Result)
+	partOneChannel := make(chan Result)
+	partTwoChannel := make(chan Result)
+
+	go doExampleOne(exampleChannelOne, &waitGroup)
+	go doExampleTwo(exampleChannelTwo, &waitGroup)
+	go doPartOne(partOneChannel, &waitGroup)
+	go doPartTwo(partTwoChannel, &waitGroup)
+
+	exampleResultOne := <-exampleChannelOne
+	exampleResultTwo := <-exampleChannelTwo
+	partOneResult := <-partOneChannel
+	partTwoResult := <-partTwoChannel
+
+	waitGroup.Wait()
+
+	event := log.Info()
+	event = logResult(event, "example-one", exampleResultOne)
+	event = logResult(event, "example-two", exampleResultTwo)
+	event = logResult(event, "part-one", partOneResult)
+	event = logResult(event, "part-two", partTwoResult)
+	event.Msg("Solved!")
+}
+
+// logResult adds a result's answer and duration, or, when it could not be found, why not.
+func logResult(event *zerolog.Event, name string, result Result) *zerolog.Event {
+	if result.err != nil {
+		return event.Str(name+"-skipped", result.err.Error())
+	}
+	return event.Int(name+"-answer", result.answer).Int64(name+"-duration", result.duration)
+}
+
+/*
+	Executors
+*/
+
+func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup) {
+	start := time.Now()
+
+	answer, err := FindSolutionForInput("example-input.dat", Packet.VersionSum)
+
+	channel <- Result{
+		answer:   answer,
+		duration: time.Since(start).Nanoseconds(),
+		err:      err,
+	}
+	waitGroup.Done()
+}
+
+func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+	start := time.Now()
+
+	answer, err := FindSolutionForInput("example-input.dat", Packet.Evaluate)
+
+	channel <- Result{
+		answer:   answer,
+		duration: time.Since(start).Nanoseconds(),
+		err:      err,
+	}
+	waitGroup.Done()
+}
+
+func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
+	start := time.Now()
+
+	answer, err := FindSolutionForInput("puzzle-input.dat", Packet.VersionSum)
+
+	channel <- Result{
+		answer:   answer,
+		duration: time.Since(start).Nanoseconds(),
+		err:      err,
+	}
+	waitGroup.Done()
+}
+
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+	start := time.Now()
+
+	answer, err := FindSolutionForInput("puzzle-input.dat", Packet.Evaluate)
+
+	channel <- Result{
+		answer:   answer,
+		duration: time.Since(start).Nanoseconds(),
+		err:      err,
+	}
+	waitGroup.Done()
+}
+
+func loadPuzzleInput(filename string) string {
+	return support.ReadFile(filename)
+}
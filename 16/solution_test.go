@@ -0,0 +1,129 @@
+package main
+
+import (
+	"advent-of-code-2021/utility/bits"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTransmission_Literal(t *testing.T) {
+	packet, err := ParseTransmission("D2FE28")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if packet.Version != 6 || packet.TypeId != TypeLiteral || packet.Value != 2021 {
+		t.Logf("Expected a version 6 literal of 2021, got %+v", packet)
+		t.Fail()
+	}
+}
+
+func TestParseTransmission_Operators(t *testing.T) {
+	for _, testCase := range []struct {
+		transmission string
+		values       []int
+	}{
+		{"38006F45291200", []int{10, 20}},
+		{"EE00D40C823060", []int{1, 2, 3}},
+	} {
+		packet, err := ParseTransmission(testCase.transmission)
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		if len(packet.SubPackets) != len(testCase.values) {
+			t.Logf("%v: expected %v sub-packets, got %v", testCase.transmission, len(testCase.values), len(packet.SubPackets))
+			t.FailNow()
+		}
+
+		for index, expected := range testCase.values {
+			if packet.SubPackets[index].Value != expected {
+				t.Logf("%v: expected sub-packet %v to be %v, got %v", testCase.transmission, index, expected, packet.SubPackets[index].Value)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestPacket_VersionSum(t *testing.T) {
+	for transmission, expected := range map[string]int{
+		"8A004A801A8002F478":             16,
+		"620080001611562C8802118E34":     12,
+		"C0015000016115A2E0802F182340":   23,
+		"A0016C880162017C3686B18A3D4780": 31,
+	} {
+		packet, err := ParseTransmission(transmission)
+		if err != nil || packet.VersionSum() != expected {
+			t.Logf("%v: expected %v, got %v (%v)", transmission, expected, packet.VersionSum(), err)
+			t.Fail()
+		}
+	}
+}
+
+func TestPacket_Evaluate(t *testing.T) {
+	for transmission, expected := range map[string]int{
+		"C200B40A82":                 3,
+		"04005AC33890":               54,
+		"880086C3E88112":             7,
+		"CE00C43D881120":             9,
+		"D8005AC2A8F0":               1,
+		"F600BC2D8F":                 0,
+		"9C005AC2F8F0":               0,
+		"9C0141080250320F1802104A08": 1,
+	} {
+		packet, err := ParseTransmission(transmission)
+		if err != nil || packet.Evaluate() != expected {
+			t.Logf("%v: expected %v, got %v (%v)", transmission, expected, packet.Evaluate(), err)
+			t.Fail()
+		}
+	}
+}
+
+func TestParseTransmission_Truncated(t *testing.T) {
+	if _, err := ParseTransmission("38006F4529"); !errors.Is(err, bits.ErrOverrun) {
+		t.Logf("Expected an overrun, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestParseTransmission_BadOperands(t *testing.T) {
+	for name, transmission := range map[string]string{
+		"a comparison with one sub-packet": "16004408",
+		"a minimum with no sub-packets":    "0A000",
+	} {
+		if _, err := ParseTransmission(transmission); err == nil {
+			t.Logf("Expected an error for %v", name)
+			t.Fail()
+		}
+	}
+}
+
+func TestFindSolutionForInput_NoInput(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.dat")
+	_ = os.WriteFile(empty, nil, 0644)
+
+	for _, filename := range []string{empty, filepath.Join(t.TempDir(), "missing.dat")} {
+		if _, err := FindSolutionForInput(filename, Packet.VersionSum); !errors.Is(err, ErrNoInput) {
+			t.Logf("%v: expected no input, got %v", filename, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestParseTransmission_LargestLiteral(t *testing.T) {
+	packet, err := ParseTransmission("12FFFFFFFFFFFFFFFFFFBC")
+	if err != nil || packet.Value != math.MaxInt {
+		t.Logf("Expected %v, got %v (%v)", math.MaxInt, packet.Value, err)
+		t.Fail()
+	}
+
+	if _, err := ParseTransmission("1310842108421084210800"); err == nil {
+		t.Log("Expected an error for a literal one larger than an int holds")
+		t.Fail()
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"advent-of-code-2021/utility/memo"
+	"flag"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"sync"
@@ -76,6 +78,83 @@ func FindSolutionForInput(playerOneStart int, playerTwoStart int) int {
 	return roller.rolls * players[playerIndex].score
 }
 
+/*
+	Part Two, Dirac Dice
+*/
+
+const DiracWinningScore = 21
+
+// DiracRollFrequencies
+// Three rolls of a three-sided die split the universe 27 ways, but only into seven distinct totals.
+var DiracRollFrequencies = map[int]int{3: 1, 4: 3, 5: 6, 6: 7, 7: 6, 8: 3, 9: 1}
+
+// DiracGame is always from the point of view of the player about to roll.
+type DiracGame struct {
+	space, score           int
+	otherSpace, otherScore int
+}
+
+type DiracWins struct {
+	current, other int
+}
+
+func NewDiracGame(playerOneStart int, playerTwoStart int) DiracGame {
+	return DiracGame{space: playerOneStart, otherSpace: playerTwoStart}
+}
+
+func (dg DiracGame) Move(roll int) DiracGame {
+	space := (dg.space+roll-1)%10 + 1
+	return DiracGame{
+		space:      dg.otherSpace,
+		score:      dg.otherScore,
+		otherSpace: space,
+		otherScore: dg.score + space,
+	}
+}
+
+func CountDiracWins(recurse func(game DiracGame) DiracWins, game DiracGame) DiracWins {
+	wins := DiracWins{}
+
+	for roll, frequency := range DiracRollFrequencies {
+		next := game.Move(roll)
+
+		// after moving, the player who rolled is the 'other' player in the next game
+		if next.otherScore >= DiracWinningScore {
+			wins.current += frequency
+			continue
+		}
+
+		nextWins := recurse(next)
+		wins.current += frequency * nextWins.other
+		wins.other += frequency * nextWins.current
+	}
+
+	return wins
+}
+
+func FindDiracSolution(playerOneStart int, playerTwoStart int, memoLimit int) int {
+	cache := memo.NewBoundedCache[DiracGame, DiracWins](memoLimit)
+	countWins := memo.Recursive(&cache, CountDiracWins)
+
+	wins := countWins(NewDiracGame(playerOneStart, playerTwoStart))
+
+	stats := cache.Stats()
+	log.
+		Debug().
+		Int("player-one-start", playerOneStart).
+		Int("player-two-start", playerTwoStart).
+		Int("memo-hits", stats.Hits).
+		Int("memo-misses", stats.Misses).
+		Int("memo-evictions", stats.Evictions).
+		Float64("memo-hit-rate", stats.HitRate()).
+		Msg("dirac dice")
+
+	if wins.current > wins.other {
+		return wins.current
+	}
+	return wins.other
+}
+
 /*
 	Main
 */
@@ -88,6 +167,15 @@ type Result struct {
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
+	verbose := flag.Bool("verbose", false, "log memoization statistics")
+	memoLimit := flag.Int("memo-limit", 0, "most game states to remember at once, 0 for no limit")
+	flag.Parse()
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	if *verbose {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	}
+
 	waitCount := 4
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(waitCount)
@@ -98,9 +186,9 @@ func main() {
 	partTwoChannel := make(chan Result)
 
 	go doExampleOne(exampleChannelOne, &waitGroup)
-	go doExampleTwo(exampleChannelTwo, &waitGroup)
+	go doExampleTwo(exampleChannelTwo, &waitGroup, *memoLimit)
 	go doPartOne(partOneChannel, &waitGroup)
-	go doPartTwo(partTwoChannel, &waitGroup)
+	go doPartTwo(partTwoChannel, &waitGroup, *memoLimit)
 
 	exampleResultOne := <-exampleChannelOne
 	exampleResultTwo := <-exampleChannelTwo
@@ -136,11 +224,11 @@ func doExampleOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	waitGroup.Done()
 }
 
-func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doExampleTwo(channel chan Result, waitGroup *sync.WaitGroup, memoLimit int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   0, // FindSolutionForInput("example-input.dat"),
+		answer:   FindDiracSolution(4, 8, memoLimit),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -156,11 +244,11 @@ func doPartOne(channel chan Result, waitGroup *sync.WaitGroup) {
 	waitGroup.Done()
 }
 
-func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup) {
+func doPartTwo(channel chan Result, waitGroup *sync.WaitGroup, memoLimit int) {
 	start := time.Now()
 
 	channel <- Result{
-		answer:   0, //  FindSolutionForInput("puzzle-input.dat"),
+		answer:   FindDiracSolution(2, 10, memoLimit),
 		duration: time.Since(start).Nanoseconds(),
 	}
 	waitGroup.Done()
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"advent-of-code-2021/utility/graph"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+	aoc gathers the tasks that work across days rather than solving one of them.
+	Run it from the repository root.
+
+		aoc viz 12 --format dot|mermaid|svg [--input puzzle-input.dat] [--highlight start,A,end]... [--paths 3]
+*/
+
+type highlights [][]string
+
+func (h *highlights) String() string {
+	var paths []string
+	for _, path := range *h {
+		paths = append(paths, strings.Join(path, ","))
+	}
+	return strings.Join(paths, " ")
+}
+
+func (h *highlights) Set(value string) error {
+	*h = append(*h, strings.Split(value, ","))
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "viz":
+		err = visualize(os.Args[2:])
+	default:
+		usage()
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc viz <day> --format dot|mermaid|svg [--input file] [--highlight a,b,c]... [--paths n]")
+	os.Exit(2)
+}
+
+/*
+	viz
+*/
+
+func visualize(args []string) error {
+	flags := flag.NewFlagSet("viz", flag.ExitOnError)
+	format := flags.String("format", "dot", "output format, dot, mermaid or svg")
+	input := flags.String("input", "example-input.dat", "input file within the day's directory")
+	pathCount := flags.Int("paths", 0, "highlight the first n paths found visiting small caves at most once")
+	var highlighted highlights
+	flags.Var(&highlighted, "highlight", "comma separated path to highlight, as printed by PrintPath; may be repeated")
+
+	if len(args) == 0 {
+		usage()
+	}
+
+	day := args[0]
+	if strings.HasPrefix(day, "-") {
+		_ = flags.Parse(args)
+		day = flags.Arg(0)
+	} else {
+		_ = flags.Parse(args[1:])
+	}
+
+	switch day {
+	case "12":
+		return visualizeCaves(filepath.Join(day, *input), *format, highlighted, *pathCount)
+	default:
+		return fmt.Errorf("no visualization for day '%v'", day)
+	}
+}
+
+func visualizeCaves(filename string, format string, highlighted [][]string, pathCount int) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("read %v: %v", filename, err)
+	}
+
+	caves, err := graph.ParseEdgeList(strings.Split(string(content), "\n"), "-", false)
+	if err != nil {
+		return fmt.Errorf("parse %v: %v", filename, err)
+	}
+
+	if pathCount > 0 {
+		onceOnly := func(next string, visits map[string]int) bool {
+			return next == strings.ToUpper(next) || visits[next] == 0
+		}
+		found := 0
+		caves.EnumeratePaths("start", "end", onceOnly, func(path []string) {
+			if found < pathCount {
+				highlighted = append(highlighted, append([]string{}, path...))
+				found++
+			}
+		})
+	}
+
+	options := graph.ExportOptions[string]{
+		Name:  "day 12",
+		Style: caveStyle,
+		Paths: highlighted,
+	}
+
+	switch format {
+	case "dot":
+		fmt.Print(caves.ToDOT(options))
+	case "mermaid":
+		fmt.Print(caves.ToMermaid(options))
+	case "svg":
+		svg := caves.ToSVG(options, 16)
+		fmt.Print(svg.String())
+	default:
+		return fmt.Errorf("unknown format '%v', expected dot, mermaid or svg", format)
+	}
+
+	return nil
+}
+
+func caveStyle(cave string) graph.VertexStyle {
+	switch {
+	case cave == "start" || cave == "end":
+		return graph.VertexStyle{Shape: graph.ShapeDoubleCircle}
+	case cave == strings.ToUpper(cave):
+		return graph.VertexStyle{Shape: graph.ShapeBox, FillColor: "#dddddd"}
+	default:
+		return graph.VertexStyle{Shape: graph.ShapeEllipse}
+	}
+}
@@ -1,8 +1,8 @@
 module advent-of-code-2021
 
-go 1.15
+go 1.18
 
 require (
-	github.com/ciroque/advent-of-code-2020 v0.0.0-20210116235623-c8d9dfe67a9c // indirect
-	github.com/rs/zerolog v1.25.0 // indirect
+	github.com/ciroque/advent-of-code-2020 v0.0.0-20210116235623-c8d9dfe67a9c
+	github.com/rs/zerolog v1.25.0
 )
@@ -0,0 +1,234 @@
+package bits
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	mathbits "math/bits"
+	"strings"
+)
+
+// BitSet is a fixed width run of bits, as wide as it needs to be.
+// Position 0 is the least significant bit; when written out, as binary or hex, the most significant bit comes first.
+// Reading methods take a BitSet by value, but a copy still shares its storage, so use Clone before changing one.
+
+const wordSize = 64
+
+type BitSet struct {
+	width int
+	words []uint64
+}
+
+func New(width int) BitSet {
+	return BitSet{
+		width: width,
+		words: make([]uint64, (width+wordSize-1)/wordSize),
+	}
+}
+
+func FromUint64(value uint64, width int) BitSet {
+	set := New(width)
+	if len(set.words) > 0 {
+		set.words[0] = value
+		set.clearUnused()
+	}
+	return set
+}
+
+// Parse reads a string of 0s and 1s, most significant first, into a BitSet as wide as the string is long.
+func Parse(binary string) (BitSet, error) {
+	set := New(len(binary))
+	for index, char := range binary {
+		switch char {
+		case '1':
+			set.Set(len(binary)-1-index, true)
+		case '0':
+		default:
+			return BitSet{}, fmt.Errorf("invalid binary digit '%c' at %v in '%v'", char, index, binary)
+		}
+	}
+	return set, nil
+}
+
+// ParseHex reads hexadecimal digits, most significant first, into a BitSet four bits wide per digit.
+func ParseHex(hex string) (BitSet, error) {
+	set := New(len(hex) * 4)
+	for index, char := range hex {
+		nibble, valid := hexNibble(char)
+		if !valid {
+			return BitSet{}, fmt.Errorf("invalid hex digit '%c' at %v in '%v'", char, index, hex)
+		}
+
+		lowest := (len(hex) - 1 - index) * 4
+		for offset := 0; offset < 4; offset++ {
+			set.Set(lowest+offset, nibble&(1<<offset) != 0)
+		}
+	}
+	return set, nil
+}
+
+// CountOnes returns, for every position, how many of sets have that bit set.
+// sets are expected to share a width; the result is as wide as the widest of them.
+func CountOnes(sets []BitSet) []int {
+	width := 0
+	for _, set := range sets {
+		if set.width > width {
+			width = set.width
+		}
+	}
+
+	counts := make([]int, width)
+	for _, set := range sets {
+		for wordIndex, word := range set.words {
+			for word != 0 {
+				offset := mathbits.TrailingZeros64(word)
+				counts[wordIndex*wordSize+offset]++
+				word &= word - 1
+			}
+		}
+	}
+	return counts
+}
+
+func (b BitSet) BigInt() *big.Int {
+	value := new(big.Int)
+	for index := len(b.words) - 1; index >= 0; index-- {
+		value.Lsh(value, wordSize)
+		value.Or(value, new(big.Int).SetUint64(b.words[index]))
+	}
+	return value
+}
+
+func (b BitSet) Clone() BitSet {
+	clone := New(b.width)
+	copy(clone.words, b.words)
+	return clone
+}
+
+func (b BitSet) Equal(other BitSet) bool {
+	if b.width != other.width {
+		return false
+	}
+	for index := range b.words {
+		if b.words[index] != other.words[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) Flip(position int) {
+	b.checkPosition(position)
+	b.words[position/wordSize] ^= 1 << (position % wordSize)
+}
+
+func (b BitSet) Get(position int) bool {
+	b.checkPosition(position)
+	return b.words[position/wordSize]&(1<<(position%wordSize)) != 0
+}
+
+func (b BitSet) Hex() string {
+	digits := (b.width + 3) / 4
+	var builder strings.Builder
+	for digit := digits - 1; digit >= 0; digit-- {
+		nibble := 0
+		for offset := 3; offset >= 0; offset-- {
+			position := digit*4 + offset
+			nibble <<= 1
+			if position < b.width && b.Get(position) {
+				nibble |= 1
+			}
+		}
+		builder.WriteByte("0123456789ABCDEF"[nibble])
+	}
+	return builder.String()
+}
+
+// Not returns a copy with every bit within the width flipped.
+func (b BitSet) Not() BitSet {
+	inverted := New(b.width)
+	for index, word := range b.words {
+		inverted.words[index] = ^word
+	}
+	inverted.clearUnused()
+	return inverted
+}
+
+func (b BitSet) OnesCount() int {
+	count := 0
+	for _, word := range b.words {
+		count += mathbits.OnesCount64(word)
+	}
+	return count
+}
+
+func (b *BitSet) Set(position int, value bool) {
+	b.checkPosition(position)
+	if value {
+		b.words[position/wordSize] |= 1 << (position % wordSize)
+	} else {
+		b.words[position/wordSize] &^= 1 << (position % wordSize)
+	}
+}
+
+// String writes the bits out as 0s and 1s, most significant first, padded to the full width.
+func (b BitSet) String() string {
+	var builder strings.Builder
+	for position := b.width - 1; position >= 0; position-- {
+		if b.Get(position) {
+			builder.WriteByte('1')
+		} else {
+			builder.WriteByte('0')
+		}
+	}
+	return builder.String()
+}
+
+// Uint64 returns the value, failing if any bit above the lowest 64 is set.
+func (b BitSet) Uint64() (uint64, error) {
+	for _, word := range b.words[min(1, len(b.words)):] {
+		if word != 0 {
+			return 0, errors.New("value does not fit in 64 bits")
+		}
+	}
+	if len(b.words) == 0 {
+		return 0, nil
+	}
+	return b.words[0], nil
+}
+
+func (b BitSet) Width() int {
+	return b.width
+}
+
+func (b BitSet) checkPosition(position int) {
+	if position < 0 || position >= b.width {
+		panic(fmt.Sprintf("bit %v is outside of a %v bit wide set", position, b.width))
+	}
+}
+
+func (b *BitSet) clearUnused() {
+	if used := b.width % wordSize; used != 0 {
+		b.words[len(b.words)-1] &= (1 << used) - 1
+	}
+}
+
+func hexNibble(char rune) (byte, bool) {
+	switch {
+	case char >= '0' && char <= '9':
+		return byte(char - '0'), true
+	case char >= 'a' && char <= 'f':
+		return byte(char-'a') + 10, true
+	case char >= 'A' && char <= 'F':
+		return byte(char-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
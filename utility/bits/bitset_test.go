@@ -0,0 +1,116 @@
+package bits
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	set, err := Parse("10110")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if value, _ := set.Uint64(); value != 22 || set.Width() != 5 {
+		t.Logf("Expected 22 five bits wide, got %v %v bits wide", value, set.Width())
+		t.Fail()
+	}
+
+	if set.String() != "10110" {
+		t.Logf("Expected 10110, got %v", set.String())
+		t.Fail()
+	}
+
+	if _, err := Parse("102"); err == nil {
+		t.Log("Expected an error for a non-binary digit")
+		t.Fail()
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	set, err := ParseHex("D2FE28")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if set.String() != "110100101111111000101000" {
+		t.Logf("Expected 110100101111111000101000, got %v", set.String())
+		t.Fail()
+	}
+
+	if set.Hex() != "D2FE28" {
+		t.Logf("Expected D2FE28, got %v", set.Hex())
+		t.Fail()
+	}
+}
+
+func TestBitSet_GetSetFlip(t *testing.T) {
+	set := New(70)
+	set.Set(69, true)
+	set.Flip(0)
+	set.Flip(1)
+	set.Flip(1)
+
+	if !set.Get(69) || !set.Get(0) || set.Get(1) || set.OnesCount() != 2 {
+		t.Logf("Unexpected bits %v", set.String())
+		t.Fail()
+	}
+
+	if _, err := set.Uint64(); err == nil {
+		t.Log("Expected a 70 bit value not to fit in a uint64")
+		t.Fail()
+	}
+
+	expected := new(big.Int).Lsh(big.NewInt(1), 69)
+	expected.Add(expected, big.NewInt(1))
+	if set.BigInt().Cmp(expected) != 0 {
+		t.Logf("Expected %v, got %v", expected, set.BigInt())
+		t.Fail()
+	}
+}
+
+func TestBitSet_NotStaysWithinWidth(t *testing.T) {
+	set, _ := Parse("10110")
+	inverted := set.Not()
+
+	if inverted.String() != "01001" || inverted.OnesCount() != 2 {
+		t.Logf("Expected 01001, got %v", inverted.String())
+		t.Fail()
+	}
+
+	if set.String() != "10110" {
+		t.Log("Expected Not to leave the original alone")
+		t.Fail()
+	}
+}
+
+func TestBitSet_WideRoundTrip(t *testing.T) {
+	binary := strings.Repeat("1001", 40)
+	set, _ := Parse(binary)
+
+	if set.String() != binary || set.OnesCount() != 80 {
+		t.Logf("Expected a 160 bit round trip, got %v", set.String())
+		t.Fail()
+	}
+}
+
+func TestCountOnes(t *testing.T) {
+	var sets []BitSet
+	for _, binary := range []string{"00100", "11110", "10110"} {
+		set, _ := Parse(binary)
+		sets = append(sets, set)
+	}
+
+	expected := []int{0, 2, 3, 1, 2}
+	counts := CountOnes(sets)
+	for position, count := range expected {
+		if counts[position] != count {
+			t.Logf("Expected %v, got %v", expected, counts)
+			t.Fail()
+			break
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package bits
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BitReader reads unsigned values of any width up to 64 bits from a stream of bits, most significant bit first.
+// A sub-reader shares the stream of the reader it was taken from but can only read the bits it was given.
+
+var ErrOverrun = errors.New("read past the end of the bit stream")
+
+type BitReader struct {
+	data     []byte
+	offset   int
+	length   int
+	position int
+}
+
+func NewBitReader(data []byte) BitReader {
+	return BitReader{
+		data:   data,
+		length: len(data) * 8,
+	}
+}
+
+// NewHexBitReader reads four bits per hexadecimal digit, so an odd number of digits leaves no padding behind.
+func NewHexBitReader(hex string) (BitReader, error) {
+	data := make([]byte, (len(hex)+1)/2)
+	for index, char := range hex {
+		nibble, valid := hexNibble(char)
+		if !valid {
+			return BitReader{}, fmt.Errorf("invalid hex digit '%c' at %v in '%v'", char, index, hex)
+		}
+
+		if index%2 == 0 {
+			nibble <<= 4
+		}
+		data[index/2] |= nibble
+	}
+
+	return BitReader{
+		data:   data,
+		length: len(hex) * 4,
+	}, nil
+}
+
+func (r *BitReader) Len() int {
+	return r.length
+}
+
+// Position is the number of bits read so far.
+func (r *BitReader) Position() int {
+	return r.position
+}
+
+// Read returns the next count bits as an unsigned value; on error nothing is consumed.
+func (r *BitReader) Read(count int) (uint64, error) {
+	if count < 0 || count > 64 {
+		return 0, fmt.Errorf("cannot read %v bits at once, reads are between 0 and 64 bits", count)
+	}
+	if err := r.require(count); err != nil {
+		return 0, err
+	}
+
+	var value uint64
+	for bit := r.offset + r.position; bit < r.offset+r.position+count; bit++ {
+		value = value<<1 | uint64(r.data[bit/8]>>(7-bit%8)&1)
+	}
+	r.position += count
+
+	return value, nil
+}
+
+func (r *BitReader) ReadBool() (bool, error) {
+	value, err := r.Read(1)
+	return value == 1, err
+}
+
+func (r *BitReader) ReadInt(count int) (int, error) {
+	value, err := r.Read(count)
+	return int(value), err
+}
+
+func (r *BitReader) Remaining() int {
+	return r.length - r.position
+}
+
+func (r *BitReader) Skip(count int) error {
+	if count < 0 {
+		return fmt.Errorf("cannot skip %v bits", count)
+	}
+	if err := r.require(count); err != nil {
+		return err
+	}
+
+	r.position += count
+	return nil
+}
+
+// SubReader hands the next length bits to a new reader and moves this one past them.
+func (r *BitReader) SubReader(length int) (BitReader, error) {
+	if length < 0 {
+		return BitReader{}, fmt.Errorf("cannot take a sub-reader %v bits long", length)
+	}
+	if err := r.require(length); err != nil {
+		return BitReader{}, err
+	}
+
+	sub := BitReader{
+		data:   r.data,
+		offset: r.offset + r.position,
+		length: length,
+	}
+	r.position += length
+
+	return sub, nil
+}
+
+func (r *BitReader) require(count int) error {
+	if count > r.Remaining() {
+		return fmt.Errorf("%w: wanted %v bits at position %v, %v remaining", ErrOverrun, count, r.position, r.Remaining())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package bits
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBitReader_Read(t *testing.T) {
+	reader, err := NewHexBitReader("D2FE28")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	for _, expected := range []struct {
+		count int
+		value uint64
+	}{{3, 6}, {3, 4}, {5, 0b10111}, {5, 0b11110}, {5, 0b00101}} {
+		value, err := reader.Read(expected.count)
+		if err != nil || value != expected.value {
+			t.Logf("Expected %v, got %v (%v)", expected.value, value, err)
+			t.Fail()
+		}
+	}
+
+	if reader.Position() != 21 || reader.Remaining() != 3 {
+		t.Logf("Expected to be at 21 with 3 remaining, got %v with %v", reader.Position(), reader.Remaining())
+		t.Fail()
+	}
+}
+
+func TestBitReader_ReadAcrossBytes(t *testing.T) {
+	reader := NewBitReader([]byte{0x0F, 0xF0, 0xAA})
+	_ = reader.Skip(4)
+
+	if value, _ := reader.Read(8); value != 0xFF {
+		t.Logf("Expected 0xFF, got %x", value)
+		t.Fail()
+	}
+
+	if value, _ := reader.Read(12); value != 0x0AA {
+		t.Logf("Expected 0x0AA, got %x", value)
+		t.Fail()
+	}
+}
+
+func TestBitReader_Overrun(t *testing.T) {
+	reader := NewBitReader([]byte{0xFF})
+	_, _ = reader.Read(6)
+
+	if _, err := reader.Read(3); !errors.Is(err, ErrOverrun) {
+		t.Logf("Expected an overrun, got %v", err)
+		t.Fail()
+	}
+
+	if reader.Position() != 6 {
+		t.Logf("Expected a failed read to leave the position at 6, got %v", reader.Position())
+		t.Fail()
+	}
+
+	if _, err := reader.Read(65); err == nil {
+		t.Log("Expected an error reading more than 64 bits")
+		t.Fail()
+	}
+}
+
+func TestBitReader_SubReader(t *testing.T) {
+	reader, _ := NewHexBitReader("ABC")
+	sub, err := reader.SubReader(8)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if reader.Position() != 8 || sub.Len() != 8 {
+		t.Logf("Expected the parent at 8 and an 8 bit sub-reader, got %v and %v", reader.Position(), sub.Len())
+		t.Fail()
+	}
+
+	if value, _ := sub.Read(8); value != 0xAB {
+		t.Logf("Expected 0xAB, got %x", value)
+		t.Fail()
+	}
+
+	if _, err := sub.Read(1); !errors.Is(err, ErrOverrun) {
+		t.Logf("Expected the sub-reader to stop at its length, got %v", err)
+		t.Fail()
+	}
+
+	if value, _ := reader.Read(4); value != 0xC {
+		t.Logf("Expected 0xC, got %x", value)
+		t.Fail()
+	}
+}
@@ -7,18 +7,23 @@ import (
 )
 
 type BorderedIntMatrix struct {
-	borderValue, height, width int
-	matrix                     [][]int
+	borderValue int
+	bounds      geometry.Rect
+	matrix      [][]int
 }
 
 func NewBorderedIntMatrix() BorderedIntMatrix {
 	return BorderedIntMatrix{
-		width:  0,
-		height: 0,
+		bounds: geometry.EmptyRect,
 		matrix: [][]int{},
 	}
 }
 
+// Bounds covers the whole matrix, border included; Expand(-1) gives just the values that were populated.
+func (b *BorderedIntMatrix) Bounds() geometry.Rect {
+	return b.bounds
+}
+
 func (b *BorderedIntMatrix) ForEachAdjacentIn(coordinates []geometry.Coordinate, process func(coordinate geometry.Coordinate, value int) int) {
 	for _, coordinate := range coordinates {
 		for _, adjacent := range coordinate.AllAdjacent() {
@@ -40,14 +45,13 @@ func (b *BorderedIntMatrix) ForEachIn(coordinates []geometry.Coordinate, update
 
 func (b *BorderedIntMatrix) Populate(input []string, borderValue int) {
 	b.borderValue = borderValue
-	b.width = len(input[0]) + 2
-	b.height = len(input) + 2
+	b.bounds = geometry.RectFromSize(geometry.NewCoordinate(0, 0), len(input[0])+2, len(input)+2)
 
 	addPadRow := func() {
 		b.matrix = append(b.matrix, []int{})
 		rowIndex := len(b.matrix) - 1
 
-		for index := 0; index < b.width; index++ {
+		for index := 0; index < b.bounds.Width(); index++ {
 			b.matrix[rowIndex] = append(b.matrix[rowIndex], borderValue)
 		}
 	}
@@ -73,8 +77,8 @@ func (b *BorderedIntMatrix) Populate(input []string, borderValue int) {
 }
 
 func (b *BorderedIntMatrix) Print() {
-	for yi := 0; yi < b.height; yi++ {
-		for xi := 0; xi < b.width; xi++ {
+	for yi := 0; yi < b.bounds.Height(); yi++ {
+		for xi := 0; xi < b.bounds.Width(); xi++ {
 			fmt.Printf("%2v ", b.ValueAt(xi, yi))
 		}
 		fmt.Println()
@@ -83,7 +87,7 @@ func (b *BorderedIntMatrix) Print() {
 }
 
 func (b *BorderedIntMatrix) Size() int {
-	return (b.height - 2) * (b.width - 2)
+	return b.bounds.Expand(-1).Area()
 }
 
 func (b *BorderedIntMatrix) ValueAt(x, y int) int {
@@ -91,10 +95,7 @@ func (b *BorderedIntMatrix) ValueAt(x, y int) int {
 }
 
 func (b *BorderedIntMatrix) VisitEach(visit func(coordinate geometry.Coordinate, v int) int) {
-	for yi := 1; yi < b.height-1; yi++ {
-		for xi := 1; xi < b.width-1; xi++ {
-			coordinate := geometry.NewCoordinate(xi, yi)
-			b.matrix[yi][xi] = visit(coordinate, b.matrix[yi][xi])
-		}
-	}
+	b.bounds.Expand(-1).ForEach(func(coordinate geometry.Coordinate) {
+		b.matrix[coordinate.Y][coordinate.X] = visit(coordinate, b.matrix[coordinate.Y][coordinate.X])
+	})
 }
@@ -0,0 +1,113 @@
+package collections
+
+// DisjointSet is a union-find over comparable items.
+// Find uses path compression and Union attaches the smaller component to the larger one.
+// Items are added implicitly the first time they are seen by Find or Union.
+
+type DisjointSet[T comparable] struct {
+	items   []T
+	parents map[T]T
+	sizes   map[T]int
+}
+
+func NewDisjointSet[T comparable]() DisjointSet[T] {
+	return DisjointSet[T]{
+		items:   []T{},
+		parents: make(map[T]T),
+		sizes:   make(map[T]int),
+	}
+}
+
+func (d *DisjointSet[T]) Add(item T) {
+	if _, found := d.parents[item]; found {
+		return
+	}
+
+	d.items = append(d.items, item)
+	d.parents[item] = item
+	d.sizes[item] = 1
+}
+
+func (d *DisjointSet[T]) ComponentCount() int {
+	count := 0
+	for _, item := range d.items {
+		if d.parents[item] == item {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *DisjointSet[T]) ComponentSize(item T) int {
+	return d.sizes[d.Find(item)]
+}
+
+// Components groups every item by its representative.
+// Components are ordered by the first time any of their members was added, as are the members within them.
+func (d *DisjointSet[T]) Components() [][]T {
+	var components [][]T
+	indexes := make(map[T]int)
+
+	for _, item := range d.items {
+		root := d.Find(item)
+		index, found := indexes[root]
+		if !found {
+			index = len(components)
+			indexes[root] = index
+			components = append(components, []T{})
+		}
+		components[index] = append(components[index], item)
+	}
+
+	return components
+}
+
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+func (d *DisjointSet[T]) Contains(item T) bool {
+	_, found := d.parents[item]
+	return found
+}
+
+func (d *DisjointSet[T]) Find(item T) T {
+	d.Add(item)
+
+	root := item
+	for d.parents[root] != root {
+		root = d.parents[root]
+	}
+
+	for item != root {
+		next := d.parents[item]
+		d.parents[item] = root
+		item = next
+	}
+
+	return root
+}
+
+func (d *DisjointSet[T]) Len() int {
+	return len(d.items)
+}
+
+// Union merges the components containing a and b, returning false if they were already the same component.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	rootA := d.Find(a)
+	rootB := d.Find(b)
+
+	if rootA == rootB {
+		return false
+	}
+
+	if d.sizes[rootA] < d.sizes[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+
+	d.parents[rootB] = rootA
+	d.sizes[rootA] += d.sizes[rootB]
+	delete(d.sizes, rootB)
+
+	return true
+}
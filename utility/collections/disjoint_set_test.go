@@ -0,0 +1,83 @@
+package collections
+
+import "testing"
+
+func TestDisjointSet_FindUnknownItemIsItsOwnRoot(t *testing.T) {
+	set := NewDisjointSet[string]()
+
+	if root := set.Find("a"); root != "a" {
+		t.Logf("Expected a, got %v", root)
+		t.Fail()
+	}
+
+	if size := set.ComponentSize("a"); size != 1 {
+		t.Logf("Expected 1, got %v", size)
+		t.Fail()
+	}
+}
+
+func TestDisjointSet_Union(t *testing.T) {
+	set := NewDisjointSet[int]()
+
+	if !set.Union(1, 2) {
+		t.Log("Expected Union of distinct components to return true")
+		t.Fail()
+	}
+
+	if set.Union(2, 1) {
+		t.Log("Expected Union of the same component to return false")
+		t.Fail()
+	}
+
+	if !set.Connected(1, 2) {
+		t.Log("Expected 1 and 2 to be connected")
+		t.Fail()
+	}
+}
+
+func TestDisjointSet_ComponentSize(t *testing.T) {
+	set := NewDisjointSet[int]()
+
+	set.Union(1, 2)
+	set.Union(3, 4)
+	set.Union(4, 5)
+	set.Add(6)
+
+	expected := map[int]int{1: 2, 2: 2, 3: 3, 4: 3, 5: 3, 6: 1}
+	for item, size := range expected {
+		if actual := set.ComponentSize(item); actual != size {
+			t.Logf("Expected component of %v to have size %v, got %v", item, size, actual)
+			t.Fail()
+		}
+	}
+
+	set.Union(2, 5)
+	if actual := set.ComponentSize(1); actual != 5 {
+		t.Logf("Expected 5, got %v", actual)
+		t.Fail()
+	}
+}
+
+func TestDisjointSet_Components(t *testing.T) {
+	set := NewDisjointSet[string]()
+
+	set.Union("a", "b")
+	set.Add("c")
+	set.Union("d", "a")
+
+	components := set.Components()
+	if len(components) != 2 || set.ComponentCount() != 2 {
+		t.Logf("Expected 2 components, got %v", components)
+		t.Fail()
+	}
+
+	if len(components[0]) != 3 || components[0][0] != "a" || components[0][2] != "d" {
+		t.Logf("Expected [a b d], got %v", components[0])
+		t.Fail()
+	}
+
+	if len(components[1]) != 1 || components[1][0] != "c" {
+		t.Logf("Expected [c], got %v", components[1])
+		t.Fail()
+	}
+}
@@ -0,0 +1,178 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"fmt"
+	"strconv"
+)
+
+// Grid is a dense, zero-indexed, width by height store of values addressed by geometry.Coordinate.
+// Unlike BorderedIntMatrix there is no sentinel border; the neighbour helpers only ever yield coordinates that are InBounds.
+
+type Grid[T any] struct {
+	width, height int
+	cells         []T
+}
+
+func NewGrid[T any](width, height int) Grid[T] {
+	return Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+func NewFilledGrid[T any](width, height int, value T) Grid[T] {
+	grid := NewGrid[T](width, height)
+	for index := range grid.cells {
+		grid.cells[index] = value
+	}
+	return grid
+}
+
+// ParseGrid builds a Grid from lines of text, converting each rune with parse.
+// Every line is expected to be the same length as the first.
+func ParseGrid[T any](input []string, parse func(char rune) T) Grid[T] {
+	if len(input) == 0 {
+		return NewGrid[T](0, 0)
+	}
+
+	grid := NewGrid[T](len([]rune(input[0])), len(input))
+	for yi, line := range input {
+		for xi, char := range []rune(line) {
+			grid.Set(geometry.NewCoordinate(xi, yi), parse(char))
+		}
+	}
+
+	return grid
+}
+
+func ParseCharacterGrid(input []string) Grid[rune] {
+	return ParseGrid(input, func(char rune) rune { return char })
+}
+
+func ParseDigitGrid(input []string) Grid[int] {
+	return ParseGrid(input, func(char rune) int {
+		value, _ := strconv.Atoi(string(char))
+		return value
+	})
+}
+
+// MapGrid is Map for transforms that change the element type; Go methods cannot introduce type parameters.
+func MapGrid[T, U any](g Grid[T], transform func(coordinate geometry.Coordinate, value T) U) Grid[U] {
+	mapped := NewGrid[U](g.width, g.height)
+	for index, value := range g.cells {
+		mapped.cells[index] = transform(g.coordinateOf(index), value)
+	}
+	return mapped
+}
+
+func (g *Grid[T]) Adjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](g, coordinate.Adjacent())
+}
+
+func (g *Grid[T]) AllAdjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](g, coordinate.AllAdjacent())
+}
+
+func (g *Grid[T]) Bounds() geometry.Rect {
+	return geometry.RectFromSize(geometry.NewCoordinate(0, 0), g.width, g.height)
+}
+
+func (g *Grid[T]) Clone() Grid[T] {
+	clone := NewGrid[T](g.width, g.height)
+	copy(clone.cells, g.cells)
+	return clone
+}
+
+func (g *Grid[T]) Column(x int) []T {
+	column := make([]T, g.height)
+	for yi := 0; yi < g.height; yi++ {
+		column[yi] = g.cells[g.indexOf(x, yi)]
+	}
+	return column
+}
+
+func (g *Grid[T]) Coordinates() []geometry.Coordinate {
+	coordinates := make([]geometry.Coordinate, len(g.cells))
+	for index := range g.cells {
+		coordinates[index] = g.coordinateOf(index)
+	}
+	return coordinates
+}
+
+func (g *Grid[T]) Get(coordinate geometry.Coordinate) T {
+	return g.cells[g.indexOf(coordinate.X, coordinate.Y)]
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(coordinate geometry.Coordinate) bool {
+	return g.Bounds().Contains(coordinate)
+}
+
+func (g *Grid[T]) Map(transform func(coordinate geometry.Coordinate, value T) T) Grid[T] {
+	return MapGrid(*g, transform)
+}
+
+func (g *Grid[T]) Print() {
+	for yi := 0; yi < g.height; yi++ {
+		for xi := 0; xi < g.width; xi++ {
+			fmt.Printf("%2v ", g.cells[g.indexOf(xi, yi)])
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+func (g *Grid[T]) Row(y int) []T {
+	row := make([]T, g.width)
+	copy(row, g.cells[g.indexOf(0, y):g.indexOf(0, y)+g.width])
+	return row
+}
+
+func (g *Grid[T]) Set(coordinate geometry.Coordinate, value T) {
+	g.cells[g.indexOf(coordinate.X, coordinate.Y)] = value
+}
+
+func (g *Grid[T]) Size() int {
+	return len(g.cells)
+}
+
+func (g *Grid[T]) VisitAdjacent(coordinate geometry.Coordinate, visit func(coordinate geometry.Coordinate, value T) T) {
+	g.visitAll(g.Adjacent(coordinate), visit)
+}
+
+func (g *Grid[T]) VisitAllAdjacent(coordinate geometry.Coordinate, visit func(coordinate geometry.Coordinate, value T) T) {
+	g.visitAll(g.AllAdjacent(coordinate), visit)
+}
+
+func (g *Grid[T]) VisitEach(visit func(coordinate geometry.Coordinate, value T) T) {
+	for index, value := range g.cells {
+		g.cells[index] = visit(g.coordinateOf(index), value)
+	}
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) coordinateOf(index int) geometry.Coordinate {
+	return geometry.NewCoordinate(index%g.width, index/g.width)
+}
+
+func (g *Grid[T]) indexOf(x, y int) int {
+	if x < 0 || x >= g.width || y < 0 || y >= g.height {
+		panic(fmt.Sprintf("coordinate { %v, %v } is outside of a %vx%v grid", x, y, g.width, g.height))
+	}
+	return y*g.width + x
+}
+
+func (g *Grid[T]) visitAll(coordinates []geometry.Coordinate, visit func(coordinate geometry.Coordinate, value T) T) {
+	for _, coordinate := range coordinates {
+		index := g.indexOf(coordinate.X, coordinate.Y)
+		g.cells[index] = visit(coordinate, g.cells[index])
+	}
+}
@@ -0,0 +1,136 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"testing"
+)
+
+var gridInput = []string{
+	"123",
+	"456",
+}
+
+func TestParseDigitGrid(t *testing.T) {
+	grid := ParseDigitGrid(gridInput)
+
+	if grid.Width() != 3 || grid.Height() != 2 {
+		t.Logf("Expected 3x2, got %vx%v", grid.Width(), grid.Height())
+		t.Fail()
+	}
+
+	if value := grid.Get(geometry.NewCoordinate(2, 1)); value != 6 {
+		t.Logf("Expected 6, got %v", value)
+		t.Fail()
+	}
+}
+
+func TestParseCharacterGrid(t *testing.T) {
+	grid := ParseCharacterGrid([]string{"#.", ".#"})
+
+	if value := grid.Get(geometry.NewCoordinate(1, 1)); value != '#' {
+		t.Logf("Expected #, got %c", value)
+		t.Fail()
+	}
+}
+
+func TestGrid_InBounds(t *testing.T) {
+	grid := NewGrid[int](3, 2)
+
+	for _, coordinate := range []geometry.Coordinate{{X: -1, Y: 0}, {X: 3, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: -1}} {
+		if grid.InBounds(coordinate) {
+			t.Logf("Expected %v to be out of bounds", coordinate)
+			t.Fail()
+		}
+	}
+
+	if !grid.InBounds(geometry.NewCoordinate(2, 1)) {
+		t.Log("Expected { 2, 1 } to be in bounds")
+		t.Fail()
+	}
+}
+
+func TestGrid_AdjacentRespectsEdges(t *testing.T) {
+	grid := NewGrid[int](3, 2)
+
+	if count := len(grid.Adjacent(geometry.NewCoordinate(0, 0))); count != 2 {
+		t.Logf("Expected 2 adjacent to a corner, got %v", count)
+		t.Fail()
+	}
+
+	if count := len(grid.AllAdjacent(geometry.NewCoordinate(0, 0))); count != 3 {
+		t.Logf("Expected 3 of all adjacent to a corner, got %v", count)
+		t.Fail()
+	}
+
+	if count := len(grid.AllAdjacent(geometry.NewCoordinate(1, 0))); count != 5 {
+		t.Logf("Expected 5 of all adjacent to an edge, got %v", count)
+		t.Fail()
+	}
+}
+
+func TestGrid_VisitAllAdjacent(t *testing.T) {
+	grid := NewGrid[int](3, 3)
+
+	grid.VisitAllAdjacent(geometry.NewCoordinate(1, 1), func(_ geometry.Coordinate, v int) int { return v + 1 })
+
+	sum := 0
+	grid.VisitEach(func(_ geometry.Coordinate, v int) int {
+		sum += v
+		return v
+	})
+
+	if sum != 8 || grid.Get(geometry.NewCoordinate(1, 1)) != 0 {
+		t.Logf("Expected only the 8 neighbours to be incremented, got sum %v", sum)
+		t.Fail()
+	}
+}
+
+func TestGrid_RowAndColumn(t *testing.T) {
+	grid := ParseDigitGrid(gridInput)
+
+	row := grid.Row(1)
+	if len(row) != 3 || row[0] != 4 || row[2] != 6 {
+		t.Logf("Expected [4 5 6], got %v", row)
+		t.Fail()
+	}
+
+	column := grid.Column(1)
+	if len(column) != 2 || column[0] != 2 || column[1] != 5 {
+		t.Logf("Expected [2 5], got %v", column)
+		t.Fail()
+	}
+
+	row[0] = 0
+	if grid.Get(geometry.NewCoordinate(0, 1)) != 4 {
+		t.Log("Expected Row to return a copy")
+		t.Fail()
+	}
+}
+
+func TestGrid_CloneIsIndependent(t *testing.T) {
+	grid := ParseDigitGrid(gridInput)
+	clone := grid.Clone()
+
+	clone.Set(geometry.NewCoordinate(0, 0), 9)
+
+	if grid.Get(geometry.NewCoordinate(0, 0)) != 1 {
+		t.Log("Expected original to be unchanged by Set on the clone")
+		t.Fail()
+	}
+}
+
+func TestMapGrid(t *testing.T) {
+	grid := ParseDigitGrid(gridInput)
+
+	mapped := MapGrid(grid, func(_ geometry.Coordinate, v int) bool { return v%2 == 0 })
+	if !mapped.Get(geometry.NewCoordinate(1, 0)) || mapped.Get(geometry.NewCoordinate(0, 0)) {
+		t.Log("Expected even values to map to true")
+		t.Fail()
+	}
+
+	doubled := grid.Map(func(_ geometry.Coordinate, v int) int { return v * 2 })
+	if doubled.Get(geometry.NewCoordinate(2, 1)) != 12 {
+		t.Logf("Expected 12, got %v", doubled.Get(geometry.NewCoordinate(2, 1)))
+		t.Fail()
+	}
+}
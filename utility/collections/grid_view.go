@@ -0,0 +1,155 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+)
+
+// GridView is the read-only face shared by Grid and the views below.
+// The views never copy their base; every Get is computed from it on demand.
+
+type GridView[T any] interface {
+	Get(coordinate geometry.Coordinate) T
+	Height() int
+	InBounds(coordinate geometry.Coordinate) bool
+	Width() int
+}
+
+// Materialize copies every in-bounds value of a view, from { 0, 0 } to { Width - 1, Height - 1 }, into a new Grid.
+func Materialize[T any](view GridView[T]) Grid[T] {
+	grid := NewGrid[T](view.Width(), view.Height())
+	grid.VisitEach(func(coordinate geometry.Coordinate, _ T) T {
+		return view.Get(coordinate)
+	})
+	return grid
+}
+
+func inBoundsOnly[T any](view GridView[T], coordinates []geometry.Coordinate) []geometry.Coordinate {
+	var inBounds []geometry.Coordinate
+	for _, coordinate := range coordinates {
+		if view.InBounds(coordinate) {
+			inBounds = append(inBounds, coordinate)
+		}
+	}
+	return inBounds
+}
+
+/*
+	TiledGrid
+*/
+
+// TiledGrid repeats its base tilesX times across and tilesY times down.
+// Each value is passed through transform along with the tile it falls in, { 0, 0 } being the original.
+
+type TiledGrid[T any] struct {
+	base           GridView[T]
+	tilesX, tilesY int
+	transform      func(value T, tile geometry.Coordinate) T
+}
+
+func NewTiledGrid[T any](base GridView[T], tilesX, tilesY int, transform func(value T, tile geometry.Coordinate) T) TiledGrid[T] {
+	return TiledGrid[T]{
+		base:      base,
+		tilesX:    tilesX,
+		tilesY:    tilesY,
+		transform: transform,
+	}
+}
+
+func (t *TiledGrid[T]) Adjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](t, coordinate.Adjacent())
+}
+
+func (t *TiledGrid[T]) AllAdjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](t, coordinate.AllAdjacent())
+}
+
+func (t *TiledGrid[T]) Get(coordinate geometry.Coordinate) T {
+	width := t.base.Width()
+	height := t.base.Height()
+
+	tile := geometry.NewCoordinate(coordinate.X/width, coordinate.Y/height)
+	value := t.base.Get(geometry.NewCoordinate(coordinate.X%width, coordinate.Y%height))
+
+	return t.transform(value, tile)
+}
+
+func (t *TiledGrid[T]) Height() int {
+	return t.base.Height() * t.tilesY
+}
+
+func (t *TiledGrid[T]) InBounds(coordinate geometry.Coordinate) bool {
+	return coordinate.X >= 0 && coordinate.X < t.Width() && coordinate.Y >= 0 && coordinate.Y < t.Height()
+}
+
+func (t *TiledGrid[T]) Width() int {
+	return t.base.Width() * t.tilesX
+}
+
+/*
+	PaddedGrid
+*/
+
+// PaddedGrid surrounds its base with padding cells of defaultValue on every side,
+// shifting the base so that { 0, 0 } is the top left of the padding.
+// An infinite PaddedGrid answers every coordinate, returning defaultValue for anything outside of its base;
+// Width and Height then describe only the padded region, which is what Materialize will copy.
+
+type PaddedGrid[T any] struct {
+	base         GridView[T]
+	defaultValue T
+	infinite     bool
+	padding      int
+}
+
+func NewPaddedGrid[T any](base GridView[T], padding int, defaultValue T) PaddedGrid[T] {
+	return PaddedGrid[T]{
+		base:         base,
+		defaultValue: defaultValue,
+		padding:      padding,
+	}
+}
+
+func NewInfiniteGrid[T any](base GridView[T], padding int, defaultValue T) PaddedGrid[T] {
+	grid := NewPaddedGrid(base, padding, defaultValue)
+	grid.infinite = true
+	return grid
+}
+
+func (p *PaddedGrid[T]) Adjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](p, coordinate.Adjacent())
+}
+
+func (p *PaddedGrid[T]) AllAdjacent(coordinate geometry.Coordinate) []geometry.Coordinate {
+	return inBoundsOnly[T](p, coordinate.AllAdjacent())
+}
+
+func (p *PaddedGrid[T]) DefaultValue() T {
+	return p.defaultValue
+}
+
+func (p *PaddedGrid[T]) Get(coordinate geometry.Coordinate) T {
+	inBase := geometry.NewCoordinate(coordinate.X-p.padding, coordinate.Y-p.padding)
+	if p.base.InBounds(inBase) {
+		return p.base.Get(inBase)
+	}
+	return p.defaultValue
+}
+
+func (p *PaddedGrid[T]) Height() int {
+	return p.base.Height() + 2*p.padding
+}
+
+func (p *PaddedGrid[T]) InBounds(coordinate geometry.Coordinate) bool {
+	if p.infinite {
+		return true
+	}
+	return coordinate.X >= 0 && coordinate.X < p.Width() && coordinate.Y >= 0 && coordinate.Y < p.Height()
+}
+
+func (p *PaddedGrid[T]) IsInfinite() bool {
+	return p.infinite
+}
+
+func (p *PaddedGrid[T]) Width() int {
+	return p.base.Width() + 2*p.padding
+}
@@ -0,0 +1,101 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"testing"
+)
+
+func wrapRisk(value int, tile geometry.Coordinate) int {
+	return (value+tile.X+tile.Y-1)%9 + 1
+}
+
+func TestTiledGrid_Dimensions(t *testing.T) {
+	base := ParseDigitGrid([]string{"18", "89"})
+	tiled := NewTiledGrid[int](&base, 5, 3, wrapRisk)
+
+	if tiled.Width() != 10 || tiled.Height() != 6 {
+		t.Logf("Expected 10x6, got %vx%v", tiled.Width(), tiled.Height())
+		t.Fail()
+	}
+
+	if tiled.InBounds(geometry.NewCoordinate(10, 0)) || !tiled.InBounds(geometry.NewCoordinate(9, 5)) {
+		t.Log("Expected bounds to cover exactly the tiled area")
+		t.Fail()
+	}
+}
+
+func TestTiledGrid_GetAppliesTransformPerTile(t *testing.T) {
+	base := ParseDigitGrid([]string{"1163751742"})
+	tiled := NewTiledGrid[int](&base, 5, 5, wrapRisk)
+
+	expected := "11637517422274862853338597396444961841755517295286"
+	for xi, char := range expected {
+		if value := tiled.Get(geometry.NewCoordinate(xi, 0)); value != int(char-'0') {
+			t.Logf("Expected %c at %v, got %v", char, xi, value)
+			t.Fail()
+		}
+	}
+
+	if value := tiled.Get(geometry.NewCoordinate(9, 4)); value != 6 {
+		t.Logf("Expected 6, got %v", value)
+		t.Fail()
+	}
+}
+
+func TestPaddedGrid_Get(t *testing.T) {
+	base := ParseCharacterGrid([]string{"#"})
+	padded := NewPaddedGrid[rune](&base, 2, '.')
+
+	if padded.Width() != 5 || padded.Height() != 5 {
+		t.Logf("Expected 5x5, got %vx%v", padded.Width(), padded.Height())
+		t.Fail()
+	}
+
+	if value := padded.Get(geometry.NewCoordinate(2, 2)); value != '#' {
+		t.Logf("Expected # at the centre, got %c", value)
+		t.Fail()
+	}
+
+	if value := padded.Get(geometry.NewCoordinate(0, 0)); value != '.' {
+		t.Logf("Expected . in the padding, got %c", value)
+		t.Fail()
+	}
+
+	if padded.InBounds(geometry.NewCoordinate(-1, 0)) {
+		t.Log("Expected a finite padded grid to be bounded")
+		t.Fail()
+	}
+}
+
+func TestInfiniteGrid_AnswersEveryCoordinate(t *testing.T) {
+	base := ParseDigitGrid([]string{"12", "34"})
+	infinite := NewInfiniteGrid[int](&base, 1, 7)
+
+	if !infinite.InBounds(geometry.NewCoordinate(-1000, 1000)) {
+		t.Log("Expected an infinite grid to be unbounded")
+		t.Fail()
+	}
+
+	if value := infinite.Get(geometry.NewCoordinate(-1000, 1000)); value != 7 {
+		t.Logf("Expected 7, got %v", value)
+		t.Fail()
+	}
+
+	if count := len(infinite.AllAdjacent(geometry.NewCoordinate(0, 0))); count != 8 {
+		t.Logf("Expected 8 adjacent, got %v", count)
+		t.Fail()
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	base := ParseDigitGrid([]string{"12", "34"})
+	padded := NewPaddedGrid[int](&base, 1, 0)
+
+	grid := Materialize[int](&padded)
+
+	row := grid.Row(1)
+	if len(row) != 4 || row[0] != 0 || row[1] != 1 || row[2] != 2 || row[3] != 0 {
+		t.Logf("Expected [0 1 2 0], got %v", row)
+		t.Fail()
+	}
+}
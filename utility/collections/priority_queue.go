@@ -0,0 +1,75 @@
+package collections
+
+import "container/heap"
+
+// PriorityQueue pops the item with the lowest priority first.
+// Items with equal priority come out in the order they were pushed.
+
+type PriorityQueue[T any] struct {
+	entries *priorityEntries[T]
+	pushed  int
+}
+
+type priorityEntry[T any] struct {
+	item     T
+	priority int
+	sequence int
+}
+
+type priorityEntries[T any] []priorityEntry[T]
+
+func NewPriorityQueue[T any]() PriorityQueue[T] {
+	return PriorityQueue[T]{
+		entries: &priorityEntries[T]{},
+	}
+}
+
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return pq.entries.Len() == 0
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.entries.Len()
+}
+
+// Pop removes and returns the item with the lowest priority, along with that priority.
+func (pq *PriorityQueue[T]) Pop() (T, int, bool) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, 0, false
+	}
+
+	entry := heap.Pop(pq.entries).(priorityEntry[T])
+	return entry.item, entry.priority, true
+}
+
+func (pq *PriorityQueue[T]) Push(item T, priority int) {
+	heap.Push(pq.entries, priorityEntry[T]{item: item, priority: priority, sequence: pq.pushed})
+	pq.pushed++
+}
+
+func (e priorityEntries[T]) Len() int {
+	return len(e)
+}
+
+func (e priorityEntries[T]) Less(i, j int) bool {
+	if e[i].priority == e[j].priority {
+		return e[i].sequence < e[j].sequence
+	}
+	return e[i].priority < e[j].priority
+}
+
+func (e priorityEntries[T]) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+}
+
+func (e *priorityEntries[T]) Push(x interface{}) {
+	*e = append(*e, x.(priorityEntry[T]))
+}
+
+func (e *priorityEntries[T]) Pop() interface{} {
+	old := *e
+	last := old[len(old)-1]
+	*e = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,39 @@
+package collections
+
+import "testing"
+
+func TestPriorityQueue_PopsLowestFirst(t *testing.T) {
+	queue := NewPriorityQueue[string]()
+	queue.Push("c", 3)
+	queue.Push("a", 1)
+	queue.Push("b", 2)
+
+	for _, expected := range []string{"a", "b", "c"} {
+		if item, _, _ := queue.Pop(); item != expected {
+			t.Logf("Expected %v, got %v", expected, item)
+			t.Fail()
+		}
+	}
+}
+
+func TestPriorityQueue_TiesPopInPushOrder(t *testing.T) {
+	queue := NewPriorityQueue[int]()
+	for index := 0; index < 5; index++ {
+		queue.Push(index, 0)
+	}
+
+	for index := 0; index < 5; index++ {
+		if item, _, _ := queue.Pop(); item != index {
+			t.Logf("Expected %v, got %v", index, item)
+			t.Fail()
+		}
+	}
+}
+
+func TestPriorityQueue_PopWhenEmpty(t *testing.T) {
+	queue := NewPriorityQueue[int]()
+	if _, _, ok := queue.Pop(); ok {
+		t.Log("Expected Pop on an empty queue to report false")
+		t.Fail()
+	}
+}
@@ -0,0 +1,120 @@
+package collections
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// SlidingWindow keeps the last size values pushed into it, along with their running sum, minimum and maximum.
+// Only the window itself is held in memory; minimum and maximum are kept in monotonic queues so every Push is amortized O(1).
+
+type SlidingWindow[T Number] struct {
+	size   int
+	pushed int
+	sum    T
+
+	values             []T
+	minimums, maximums []windowEntry[T]
+}
+
+type windowEntry[T Number] struct {
+	position int
+	value    T
+}
+
+func NewSlidingWindow[T Number](size int) SlidingWindow[T] {
+	if size < 1 {
+		panic("sliding window size must be at least 1")
+	}
+
+	return SlidingWindow[T]{
+		size:   size,
+		values: make([]T, size),
+	}
+}
+
+func (w *SlidingWindow[T]) Full() bool {
+	return w.pushed >= w.size
+}
+
+func (w *SlidingWindow[T]) Len() int {
+	if w.Full() {
+		return w.size
+	}
+	return w.pushed
+}
+
+func (w *SlidingWindow[T]) Max() T {
+	if len(w.maximums) == 0 {
+		return 0
+	}
+	return w.maximums[0].value
+}
+
+func (w *SlidingWindow[T]) Mean() float64 {
+	if w.Len() == 0 {
+		return 0
+	}
+	return float64(w.sum) / float64(w.Len())
+}
+
+func (w *SlidingWindow[T]) Min() T {
+	if len(w.minimums) == 0 {
+		return 0
+	}
+	return w.minimums[0].value
+}
+
+// Push adds value to the window, evicting the oldest value once the window is full.
+func (w *SlidingWindow[T]) Push(value T) {
+	slot := w.pushed % w.size
+	if w.Full() {
+		w.sum -= w.values[slot]
+	}
+
+	w.values[slot] = value
+	w.sum += value
+
+	oldest := w.pushed - w.size + 1
+	entry := windowEntry[T]{position: w.pushed, value: value}
+
+	w.minimums = pushMonotonic(w.minimums, entry, oldest, func(queued, incoming T) bool { return queued >= incoming })
+	w.maximums = pushMonotonic(w.maximums, entry, oldest, func(queued, incoming T) bool { return queued <= incoming })
+
+	w.pushed++
+}
+
+// Pushed is the total number of values pushed, including those since evicted.
+func (w *SlidingWindow[T]) Pushed() int {
+	return w.pushed
+}
+
+func (w *SlidingWindow[T]) Size() int {
+	return w.size
+}
+
+func (w *SlidingWindow[T]) Sum() T {
+	return w.sum
+}
+
+// Values returns the values currently in the window, oldest first.
+func (w *SlidingWindow[T]) Values() []T {
+	values := make([]T, 0, w.Len())
+	for position := w.pushed - w.Len(); position < w.pushed; position++ {
+		values = append(values, w.values[position%w.size])
+	}
+	return values
+}
+
+func pushMonotonic[T Number](queue []windowEntry[T], entry windowEntry[T], oldest int, dominated func(queued, incoming T) bool) []windowEntry[T] {
+	for len(queue) > 0 && queue[0].position < oldest {
+		queue = queue[1:]
+	}
+
+	for len(queue) > 0 && dominated(queue[len(queue)-1].value, entry.value) {
+		queue = queue[:len(queue)-1]
+	}
+
+	return append(queue, entry)
+}
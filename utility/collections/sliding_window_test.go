@@ -0,0 +1,62 @@
+package collections
+
+import "testing"
+
+func TestSlidingWindow_FillsBeforeEvicting(t *testing.T) {
+	window := NewSlidingWindow[int](3)
+
+	window.Push(1)
+	window.Push(2)
+	if window.Full() || window.Len() != 2 || window.Sum() != 3 {
+		t.Logf("Expected a partial window of 2 summing to 3, got %v summing to %v", window.Len(), window.Sum())
+		t.Fail()
+	}
+
+	window.Push(3)
+	window.Push(4)
+	if !window.Full() || window.Len() != 3 || window.Sum() != 9 {
+		t.Logf("Expected a full window of 3 summing to 9, got %v summing to %v", window.Len(), window.Sum())
+		t.Fail()
+	}
+
+	values := window.Values()
+	if values[0] != 2 || values[2] != 4 {
+		t.Logf("Expected [2 3 4], got %v", values)
+		t.Fail()
+	}
+}
+
+func TestSlidingWindow_MinAndMax(t *testing.T) {
+	window := NewSlidingWindow[int](3)
+
+	inputs := []int{5, 1, 3, 4, 6, 2, 2}
+	expectedMinimums := []int{5, 1, 1, 1, 3, 2, 2}
+	expectedMaximums := []int{5, 5, 5, 4, 6, 6, 6}
+
+	for index, input := range inputs {
+		window.Push(input)
+		if window.Min() != expectedMinimums[index] || window.Max() != expectedMaximums[index] {
+			t.Logf("After pushing %v expected min %v and max %v, got %v and %v",
+				input, expectedMinimums[index], expectedMaximums[index], window.Min(), window.Max())
+			t.Fail()
+		}
+	}
+}
+
+func TestSlidingWindow_Mean(t *testing.T) {
+	window := NewSlidingWindow[float64](2)
+
+	if window.Mean() != 0 {
+		t.Log("Expected an empty window to have a mean of 0")
+		t.Fail()
+	}
+
+	window.Push(1)
+	window.Push(2)
+	window.Push(4)
+
+	if window.Mean() != 3 {
+		t.Logf("Expected 3, got %v", window.Mean())
+		t.Fail()
+	}
+}
@@ -0,0 +1,188 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"sort"
+	"strings"
+)
+
+// SparseGrid holds values only at the coordinates that have been Set.
+// The bounding box grows as values are added; removing a value on its edge marks it stale,
+// and it is recalculated the next time it is asked for.
+
+type SparseGrid[T any] struct {
+	cells       map[geometry.Coordinate]T
+	bounds      geometry.Rect
+	boundsStale bool
+}
+
+func NewSparseGrid[T any]() SparseGrid[T] {
+	return SparseGrid[T]{
+		cells:  make(map[geometry.Coordinate]T),
+		bounds: geometry.EmptyRect,
+	}
+}
+
+// Bounds is the smallest Rect containing every value, empty when there are none.
+func (s *SparseGrid[T]) Bounds() geometry.Rect {
+	s.refreshBounds()
+	return s.bounds
+}
+
+func (s *SparseGrid[T]) Contains(coordinate geometry.Coordinate) bool {
+	_, found := s.cells[coordinate]
+	return found
+}
+
+// Coordinates returns every populated coordinate, ordered top to bottom then left to right.
+func (s *SparseGrid[T]) Coordinates() []geometry.Coordinate {
+	coordinates := make([]geometry.Coordinate, 0, len(s.cells))
+	for coordinate := range s.cells {
+		coordinates = append(coordinates, coordinate)
+	}
+
+	sort.Slice(coordinates, func(i, j int) bool {
+		return coordinates[i].Less(coordinates[j])
+	})
+
+	return coordinates
+}
+
+// Crop returns a new SparseGrid holding only the values within region.
+func (s *SparseGrid[T]) Crop(region geometry.Rect) SparseGrid[T] {
+	cropped := NewSparseGrid[T]()
+	for coordinate, value := range s.cells {
+		if region.Contains(coordinate) {
+			cropped.Set(coordinate, value)
+		}
+	}
+	return cropped
+}
+
+func (s *SparseGrid[T]) Delete(coordinate geometry.Coordinate) {
+	if !s.Contains(coordinate) {
+		return
+	}
+
+	delete(s.cells, coordinate)
+
+	if coordinate.X == s.bounds.Min.X || coordinate.X == s.bounds.Max.X || coordinate.Y == s.bounds.Min.Y || coordinate.Y == s.bounds.Max.Y {
+		s.boundsStale = true
+	}
+}
+
+func (s *SparseGrid[T]) ForEach(visit func(coordinate geometry.Coordinate, value T)) {
+	for coordinate, value := range s.cells {
+		visit(coordinate, value)
+	}
+}
+
+func (s *SparseGrid[T]) Get(coordinate geometry.Coordinate) (T, bool) {
+	value, found := s.cells[coordinate]
+	return value, found
+}
+
+// Height is the number of rows spanned by the bounding box, zero when empty.
+func (s *SparseGrid[T]) Height() int {
+	return s.Bounds().Height()
+}
+
+func (s *SparseGrid[T]) Len() int {
+	return len(s.cells)
+}
+
+func (s *SparseGrid[T]) Max() geometry.Coordinate {
+	return s.Bounds().Max
+}
+
+// Merge copies every value of other into this grid, using combine where both hold a value.
+func (s *SparseGrid[T]) Merge(other SparseGrid[T], combine func(existing, incoming T) T) {
+	for coordinate, value := range other.cells {
+		s.Update(coordinate, func(existing T, found bool) T {
+			if found {
+				return combine(existing, value)
+			}
+			return value
+		})
+	}
+}
+
+func (s *SparseGrid[T]) Min() geometry.Coordinate {
+	return s.Bounds().Min
+}
+
+// Reflect mirrors every value across the line at index.
+// Following day 13's folds, geometry.Horizontal reflects X across x=index and geometry.Vertical reflects Y across y=index.
+func (s *SparseGrid[T]) Reflect(axis geometry.Axis, index int) SparseGrid[T] {
+	reflected := NewSparseGrid[T]()
+	for coordinate, value := range s.cells {
+		if axis == geometry.Horizontal {
+			coordinate = coordinate.ReflectX(index)
+		} else {
+			coordinate = coordinate.ReflectY(index)
+		}
+		reflected.Set(coordinate, value)
+	}
+	return reflected
+}
+
+// Render draws the bounding box as text, one line per row, with glyph choosing the rune for each cell.
+func (s *SparseGrid[T]) Render(glyph func(value T, found bool) rune) string {
+	if s.Len() == 0 {
+		return ""
+	}
+	return s.RenderRegion(s.Bounds(), glyph)
+}
+
+func (s *SparseGrid[T]) RenderRegion(region geometry.Rect, glyph func(value T, found bool) rune) string {
+	var builder strings.Builder
+	for y := region.Min.Y; y <= region.Max.Y; y++ {
+		for x := region.Min.X; x <= region.Max.X; x++ {
+			value, found := s.cells[geometry.NewCoordinate(x, y)]
+			builder.WriteRune(glyph(value, found))
+		}
+		builder.WriteRune('\n')
+	}
+	return builder.String()
+}
+
+func (s *SparseGrid[T]) Set(coordinate geometry.Coordinate, value T) {
+	s.cells[coordinate] = value
+
+	if s.boundsStale {
+		return
+	}
+
+	s.bounds = s.bounds.Extend(coordinate)
+}
+
+func (s *SparseGrid[T]) Translate(delta geometry.Coordinate) SparseGrid[T] {
+	translated := NewSparseGrid[T]()
+	for coordinate, value := range s.cells {
+		translated.Set(coordinate.Add(delta), value)
+	}
+	return translated
+}
+
+// Update sets the value at coordinate to whatever update returns given the current value, if any.
+func (s *SparseGrid[T]) Update(coordinate geometry.Coordinate, update func(value T, found bool) T) {
+	value, found := s.cells[coordinate]
+	s.Set(coordinate, update(value, found))
+}
+
+// Width is the number of columns spanned by the bounding box, zero when empty.
+func (s *SparseGrid[T]) Width() int {
+	return s.Bounds().Width()
+}
+
+func (s *SparseGrid[T]) refreshBounds() {
+	if !s.boundsStale {
+		return
+	}
+
+	s.boundsStale = false
+	s.bounds = geometry.EmptyRect
+	for coordinate := range s.cells {
+		s.bounds = s.bounds.Extend(coordinate)
+	}
+}
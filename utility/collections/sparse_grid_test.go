@@ -0,0 +1,123 @@
+package collections
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"testing"
+)
+
+func dotGlyph(_ bool, found bool) rune {
+	if found {
+		return '#'
+	}
+	return '.'
+}
+
+func TestSparseGrid_BoundsGrowWithSet(t *testing.T) {
+	grid := NewSparseGrid[bool]()
+
+	if grid.Width() != 0 || grid.Height() != 0 {
+		t.Log("Expected an empty grid to have no extent")
+		t.Fail()
+	}
+
+	grid.Set(geometry.NewCoordinate(2, 3), true)
+	grid.Set(geometry.NewCoordinate(-1, 5), true)
+
+	if grid.Min() != geometry.NewCoordinate(-1, 3) || grid.Max() != geometry.NewCoordinate(2, 5) {
+		t.Logf("Expected { -1, 3 } to { 2, 5 }, got %v to %v", grid.Min(), grid.Max())
+		t.Fail()
+	}
+
+	if grid.Width() != 4 || grid.Height() != 3 {
+		t.Logf("Expected 4x3, got %vx%v", grid.Width(), grid.Height())
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_DeleteShrinksBounds(t *testing.T) {
+	grid := NewSparseGrid[bool]()
+	grid.Set(geometry.NewCoordinate(0, 0), true)
+	grid.Set(geometry.NewCoordinate(9, 9), true)
+
+	grid.Delete(geometry.NewCoordinate(9, 9))
+
+	if grid.Max() != geometry.NewCoordinate(0, 0) {
+		t.Logf("Expected { 0, 0 }, got %v", grid.Max())
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_Update(t *testing.T) {
+	grid := NewSparseGrid[int]()
+	increment := func(value int, _ bool) int { return value + 1 }
+
+	grid.Update(geometry.NewCoordinate(1, 1), increment)
+	grid.Update(geometry.NewCoordinate(1, 1), increment)
+
+	if value, _ := grid.Get(geometry.NewCoordinate(1, 1)); value != 2 {
+		t.Logf("Expected 2, got %v", value)
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_Reflect(t *testing.T) {
+	grid := NewSparseGrid[bool]()
+	grid.Set(geometry.NewCoordinate(1, 10), true)
+
+	reflected := grid.Reflect(geometry.Vertical, 7)
+	if !reflected.Contains(geometry.NewCoordinate(1, 4)) {
+		t.Logf("Expected { 1, 4 }, got %v", reflected.Coordinates())
+		t.Fail()
+	}
+
+	reflected = grid.Reflect(geometry.Horizontal, 3)
+	if !reflected.Contains(geometry.NewCoordinate(5, 10)) {
+		t.Logf("Expected { 5, 10 }, got %v", reflected.Coordinates())
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_TranslateAndCrop(t *testing.T) {
+	grid := NewSparseGrid[bool]()
+	grid.Set(geometry.NewCoordinate(0, 0), true)
+	grid.Set(geometry.NewCoordinate(4, 4), true)
+
+	translated := grid.Translate(geometry.NewCoordinate(1, -1))
+	if !translated.Contains(geometry.NewCoordinate(1, -1)) || !translated.Contains(geometry.NewCoordinate(5, 3)) {
+		t.Logf("Expected translated coordinates, got %v", translated.Coordinates())
+		t.Fail()
+	}
+
+	cropped := grid.Crop(geometry.NewRect(geometry.NewCoordinate(0, 0), geometry.NewCoordinate(2, 2)))
+	if cropped.Len() != 1 || !cropped.Contains(geometry.NewCoordinate(0, 0)) {
+		t.Logf("Expected only { 0, 0 }, got %v", cropped.Coordinates())
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_Merge(t *testing.T) {
+	a := NewSparseGrid[int]()
+	a.Set(geometry.NewCoordinate(0, 0), 1)
+	b := NewSparseGrid[int]()
+	b.Set(geometry.NewCoordinate(0, 0), 2)
+	b.Set(geometry.NewCoordinate(1, 0), 3)
+
+	a.Merge(b, func(existing, incoming int) int { return existing + incoming })
+
+	if value, _ := a.Get(geometry.NewCoordinate(0, 0)); value != 3 || a.Len() != 2 {
+		t.Logf("Expected merged value 3 and 2 entries, got %v and %v", value, a.Len())
+		t.Fail()
+	}
+}
+
+func TestSparseGrid_Render(t *testing.T) {
+	grid := NewSparseGrid[bool]()
+	grid.Set(geometry.NewCoordinate(1, 1), true)
+	grid.Set(geometry.NewCoordinate(3, 2), true)
+
+	expected := "#..\n..#\n"
+	if actual := grid.Render(dotGlyph); actual != expected {
+		t.Logf("Expected %q, got %q", expected, actual)
+		t.Fail()
+	}
+}
@@ -1,6 +1,12 @@
 package geometry
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
+
+// Coordinate methods take a value receiver and return new Coordinates, so they can be called on map keys and chained.
+// Y grows downwards, as rows of puzzle input do, so a clockwise quarter turn takes east to south.
 
 type Coordinate struct {
 	X, Y int
@@ -10,7 +16,11 @@ func NewCoordinate(x, y int) Coordinate {
 	return Coordinate{X: x, Y: y}
 }
 
-func (c *Coordinate) Adjacent() []Coordinate {
+func (c Coordinate) Add(other Coordinate) Coordinate {
+	return Coordinate{X: c.X + other.X, Y: c.Y + other.Y}
+}
+
+func (c Coordinate) Adjacent() []Coordinate {
 	north := Coordinate{X: c.X, Y: c.Y - 1}
 	south := Coordinate{X: c.X, Y: c.Y + 1}
 	east := Coordinate{X: c.X + 1, Y: c.Y}
@@ -19,7 +29,7 @@ func (c *Coordinate) Adjacent() []Coordinate {
 	return []Coordinate{north, south, east, west}
 }
 
-func (c *Coordinate) AllAdjacent() []Coordinate {
+func (c Coordinate) AllAdjacent() []Coordinate {
 	adjacent := c.Adjacent()
 
 	adjacent = append(adjacent, Coordinate{X: c.X - 1, Y: c.Y - 1})
@@ -30,6 +40,102 @@ func (c *Coordinate) AllAdjacent() []Coordinate {
 	return adjacent
 }
 
-func (c *Coordinate) String() string {
+// Chebyshev is the number of king's moves, straight or diagonal, between two coordinates.
+func (c Coordinate) Chebyshev(other Coordinate) int {
+	delta := c.Sub(other)
+	return max(abs(delta.X), abs(delta.Y))
+}
+
+// Compare orders coordinates top to bottom then left to right, returning -1, 0 or 1.
+func (c Coordinate) Compare(other Coordinate) int {
+	switch {
+	case c.Y != other.Y:
+		return sign(c.Y - other.Y)
+	default:
+		return sign(c.X - other.X)
+	}
+}
+
+func (c Coordinate) Euclidean(other Coordinate) float64 {
+	delta := c.Sub(other)
+	return math.Hypot(float64(delta.X), float64(delta.Y))
+}
+
+func (c Coordinate) Less(other Coordinate) bool {
+	return c.Compare(other) < 0
+}
+
+func (c Coordinate) Manhattan(other Coordinate) int {
+	delta := c.Sub(other)
+	return abs(delta.X) + abs(delta.Y)
+}
+
+func (c Coordinate) Neg() Coordinate {
+	return Coordinate{X: -c.X, Y: -c.Y}
+}
+
+// ReflectX mirrors the coordinate across the vertical line x=at.
+func (c Coordinate) ReflectX(at int) Coordinate {
+	return Coordinate{X: 2*at - c.X, Y: c.Y}
+}
+
+// ReflectY mirrors the coordinate across the horizontal line y=at.
+func (c Coordinate) ReflectY(at int) Coordinate {
+	return Coordinate{X: c.X, Y: 2*at - c.Y}
+}
+
+// Rotate turns the coordinate about the origin by quarterTurns clockwise; negative turns go anticlockwise.
+func (c Coordinate) Rotate(quarterTurns int) Coordinate {
+	switch ((quarterTurns % 4) + 4) % 4 {
+	case 1:
+		return Coordinate{X: -c.Y, Y: c.X}
+	case 2:
+		return c.Neg()
+	case 3:
+		return Coordinate{X: c.Y, Y: -c.X}
+	default:
+		return c
+	}
+}
+
+func (c Coordinate) Scale(factor int) Coordinate {
+	return Coordinate{X: c.X * factor, Y: c.Y * factor}
+}
+
+// Sign reduces each component to -1, 0 or 1, turning a difference between two coordinates into a single step.
+func (c Coordinate) Sign() Coordinate {
+	return Coordinate{X: sign(c.X), Y: sign(c.Y)}
+}
+
+func (c Coordinate) String() string {
 	return fmt.Sprintf("{ %v, %v }", c.X, c.Y)
 }
+
+func (c Coordinate) Sub(other Coordinate) Coordinate {
+	return Coordinate{X: c.X - other.X, Y: c.Y - other.Y}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	default:
+		return 0
+	}
+}
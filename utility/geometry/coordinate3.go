@@ -0,0 +1,65 @@
+package geometry
+
+import "fmt"
+
+type Coordinate3 struct {
+	X, Y, Z int
+}
+
+func NewCoordinate3(x, y, z int) Coordinate3 {
+	return Coordinate3{X: x, Y: y, Z: z}
+}
+
+func (c Coordinate3) Add(other Coordinate3) Coordinate3 {
+	return Coordinate3{X: c.X + other.X, Y: c.Y + other.Y, Z: c.Z + other.Z}
+}
+
+func (c Coordinate3) Chebyshev(other Coordinate3) int {
+	delta := c.Sub(other)
+	return max(abs(delta.X), max(abs(delta.Y), abs(delta.Z)))
+}
+
+// Compare orders coordinates by Z, then Y, then X, returning -1, 0 or 1.
+func (c Coordinate3) Compare(other Coordinate3) int {
+	switch {
+	case c.Z != other.Z:
+		return sign(c.Z - other.Z)
+	case c.Y != other.Y:
+		return sign(c.Y - other.Y)
+	default:
+		return sign(c.X - other.X)
+	}
+}
+
+func (c Coordinate3) Less(other Coordinate3) bool {
+	return c.Compare(other) < 0
+}
+
+func (c Coordinate3) Manhattan(other Coordinate3) int {
+	delta := c.Sub(other)
+	return abs(delta.X) + abs(delta.Y) + abs(delta.Z)
+}
+
+func (c Coordinate3) Neg() Coordinate3 {
+	return Coordinate3{X: -c.X, Y: -c.Y, Z: -c.Z}
+}
+
+func (c Coordinate3) Scale(factor int) Coordinate3 {
+	return Coordinate3{X: c.X * factor, Y: c.Y * factor, Z: c.Z * factor}
+}
+
+func (c Coordinate3) Sign() Coordinate3 {
+	return Coordinate3{X: sign(c.X), Y: sign(c.Y), Z: sign(c.Z)}
+}
+
+func (c Coordinate3) String() string {
+	return fmt.Sprintf("{ %v, %v, %v }", c.X, c.Y, c.Z)
+}
+
+func (c Coordinate3) Sub(other Coordinate3) Coordinate3 {
+	return Coordinate3{X: c.X - other.X, Y: c.Y - other.Y, Z: c.Z - other.Z}
+}
+
+func (c Coordinate3) components() [3]int {
+	return [3]int{c.X, c.Y, c.Z}
+}
@@ -0,0 +1,99 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestCoordinate_Arithmetic(t *testing.T) {
+	a := NewCoordinate(3, -2)
+	b := NewCoordinate(-1, 5)
+
+	for _, testCase := range []struct {
+		name     string
+		actual   Coordinate
+		expected Coordinate
+	}{
+		{"Add", a.Add(b), NewCoordinate(2, 3)},
+		{"Sub", a.Sub(b), NewCoordinate(4, -7)},
+		{"Scale", a.Scale(3), NewCoordinate(9, -6)},
+		{"Neg", a.Neg(), NewCoordinate(-3, 2)},
+		{"Sign", a.Sub(b).Sign(), NewCoordinate(1, -1)},
+		{"Sign of zero", NewCoordinate(0, 7).Sign(), NewCoordinate(0, 1)},
+		{"ReflectX", a.ReflectX(5), NewCoordinate(7, -2)},
+		{"ReflectY", a.ReflectY(1), NewCoordinate(3, 4)},
+	} {
+		if testCase.actual != testCase.expected {
+			t.Logf("%v: expected %v, got %v", testCase.name, testCase.expected, testCase.actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestCoordinate_Distances(t *testing.T) {
+	a := NewCoordinate(1, 1)
+	b := NewCoordinate(4, 5)
+
+	if a.Manhattan(b) != 7 || b.Manhattan(a) != 7 {
+		t.Logf("Expected a Manhattan distance of 7, got %v", a.Manhattan(b))
+		t.Fail()
+	}
+
+	if a.Chebyshev(b) != 4 {
+		t.Logf("Expected a Chebyshev distance of 4, got %v", a.Chebyshev(b))
+		t.Fail()
+	}
+
+	if math.Abs(a.Euclidean(b)-5) > 1e-9 {
+		t.Logf("Expected a Euclidean distance of 5, got %v", a.Euclidean(b))
+		t.Fail()
+	}
+}
+
+func TestCoordinate_Rotate(t *testing.T) {
+	east := NewCoordinate(1, 0)
+
+	for turns, expected := range map[int]Coordinate{
+		0:  east,
+		1:  NewCoordinate(0, 1),
+		2:  NewCoordinate(-1, 0),
+		3:  NewCoordinate(0, -1),
+		4:  east,
+		-1: NewCoordinate(0, -1),
+		-6: NewCoordinate(-1, 0),
+	} {
+		if actual := east.Rotate(turns); actual != expected {
+			t.Logf("Rotate(%v): expected %v, got %v", turns, expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestCoordinate_Less(t *testing.T) {
+	coordinates := []Coordinate{{2, 1}, {0, 2}, {1, 1}, {5, 0}}
+	sort.Slice(coordinates, func(i, j int) bool { return coordinates[i].Less(coordinates[j]) })
+
+	expected := []Coordinate{{5, 0}, {1, 1}, {2, 1}, {0, 2}}
+	for index := range expected {
+		if coordinates[index] != expected[index] {
+			t.Logf("Expected %v, got %v", expected, coordinates)
+			t.FailNow()
+		}
+	}
+
+	if NewCoordinate(1, 1).Compare(NewCoordinate(1, 1)) != 0 {
+		t.Log("Expected equal coordinates to compare as 0")
+		t.Fail()
+	}
+}
+
+func TestCoordinate_MapKey(t *testing.T) {
+	counts := map[Coordinate]int{NewCoordinate(0, 0): 1}
+	for key := range counts {
+		if key.Add(NewCoordinate(1, 1)).Manhattan(key) != 2 {
+			t.Log("Expected methods to be callable on a map key")
+			t.Fail()
+		}
+	}
+}
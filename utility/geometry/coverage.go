@@ -0,0 +1,202 @@
+package geometry
+
+import (
+	"advent-of-code-2021/utility/intervals"
+	"fmt"
+	"sort"
+)
+
+// CountCovered counts the lattice points lying on at least threshold of the segments without visiting them one by one,
+// so it copes with segments millions of cells long.
+// Segments sharing a carrying line are swept along it as intervals; points where two carrying lines cross are then
+// corrected for, as they are the only places coverage from different lines can add up.
+// Only horizontal, vertical and 45 degree segments are supported.
+
+type carrierDirection int
+
+const (
+	carrierHorizontal carrierDirection = iota
+	carrierVertical
+	carrierDiagonal
+	carrierAntiDiagonal
+)
+
+// carrierCoefficients gives, for each direction, the a and b in a*x + b*y = key that every point on the line satisfies.
+var carrierCoefficients = [...][2]int{
+	carrierHorizontal:   {0, 1},
+	carrierVertical:     {1, 0},
+	carrierDiagonal:     {-1, 1},
+	carrierAntiDiagonal: {1, 1},
+}
+
+type carrier struct {
+	direction carrierDirection
+	key       int
+}
+
+type coveredPiece struct {
+	span  intervals.Interval
+	count int
+}
+
+func CountCovered(segments []Segment, threshold int) (int, error) {
+	spans := make(map[carrier][]intervals.Interval)
+	for _, segment := range segments {
+		line, err := carrierOf(segment)
+		if err != nil {
+			return 0, err
+		}
+		spans[line] = append(spans[line], intervals.Span(line.position(segment.Start), line.position(segment.End)))
+	}
+
+	lines := make([]carrier, 0, len(spans))
+	pieces := make(map[carrier][]coveredPiece)
+	covered := 0
+	for line, lineSpans := range spans {
+		lines = append(lines, line)
+		pieces[line] = sweep(lineSpans)
+		for _, piece := range pieces[line] {
+			if piece.count >= threshold {
+				covered += piece.span.Len()
+			}
+		}
+	}
+
+	crossings := make(map[Coordinate][]int)
+	for first := 0; first < len(lines); first++ {
+		for second := first + 1; second < len(lines); second++ {
+			point, found := lines[first].crossing(lines[second])
+			if !found {
+				continue
+			}
+
+			firstCount := coverageAt(pieces[lines[first]], lines[first].position(point))
+			secondCount := coverageAt(pieces[lines[second]], lines[second].position(point))
+			if firstCount == 0 || secondCount == 0 {
+				continue
+			}
+
+			if _, seen := crossings[point]; !seen {
+				crossings[point] = coverageThrough(point, pieces)
+			}
+		}
+	}
+
+	for _, counts := range crossings {
+		total := 0
+		for _, count := range counts {
+			total += count
+			if count >= threshold {
+				covered--
+			}
+		}
+		if total >= threshold {
+			covered++
+		}
+	}
+
+	return covered, nil
+}
+
+func carrierOf(segment Segment) (carrier, error) {
+	delta := segment.Delta()
+	var direction carrierDirection
+
+	switch {
+	case delta.Y == 0:
+		direction = carrierHorizontal
+	case delta.X == 0:
+		direction = carrierVertical
+	case delta.X == delta.Y:
+		direction = carrierDiagonal
+	case delta.X == -delta.Y:
+		direction = carrierAntiDiagonal
+	default:
+		return carrier{}, fmt.Errorf("segment %v is not horizontal, vertical or at 45 degrees", segment)
+	}
+
+	coefficients := carrierCoefficients[direction]
+	return carrier{
+		direction: direction,
+		key:       coefficients[0]*segment.Start.X + coefficients[1]*segment.Start.Y,
+	}, nil
+}
+
+// crossing finds the lattice point where two carrying lines meet, if they meet at one.
+func (c carrier) crossing(other carrier) (Coordinate, bool) {
+	a, b := carrierCoefficients[c.direction], carrierCoefficients[other.direction]
+	determinant := a[0]*b[1] - b[0]*a[1]
+	if determinant == 0 {
+		return Coordinate{}, false
+	}
+
+	x := c.key*b[1] - other.key*a[1]
+	y := a[0]*other.key - b[0]*c.key
+	if x%determinant != 0 || y%determinant != 0 {
+		return Coordinate{}, false
+	}
+
+	return NewCoordinate(x/determinant, y/determinant), true
+}
+
+// position is how far along the carrying line the coordinate lies.
+func (c carrier) position(coordinate Coordinate) int {
+	if c.direction == carrierVertical {
+		return coordinate.Y
+	}
+	return coordinate.X
+}
+
+func coverageAt(pieces []coveredPiece, position int) int {
+	index := sort.Search(len(pieces), func(index int) bool {
+		return pieces[index].span.Max >= position
+	})
+	if index < len(pieces) && pieces[index].span.Contains(position) {
+		return pieces[index].count
+	}
+	return 0
+}
+
+func coverageThrough(point Coordinate, pieces map[carrier][]coveredPiece) []int {
+	var counts []int
+	for direction := range carrierCoefficients {
+		coefficients := carrierCoefficients[direction]
+		line := carrier{
+			direction: carrierDirection(direction),
+			key:       coefficients[0]*point.X + coefficients[1]*point.Y,
+		}
+		if count := coverageAt(pieces[line], line.position(point)); count > 0 {
+			counts = append(counts, count)
+		}
+	}
+	return counts
+}
+
+// sweep turns overlapping spans into ordered, non-overlapping, pieces each covered by the same number of spans.
+func sweep(spans []intervals.Interval) []coveredPiece {
+	changes := make(map[int]int)
+	for _, span := range spans {
+		changes[span.Min]++
+		changes[span.Max+1]--
+	}
+
+	positions := make([]int, 0, len(changes))
+	for position := range changes {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+
+	var pieces []coveredPiece
+	count := 0
+	for index, position := range positions {
+		count += changes[position]
+		if count > 0 && index+1 < len(positions) {
+			pieces = append(pieces, coveredPiece{
+				span:  intervals.NewInterval(position, positions[index+1]-1),
+				count: count,
+			})
+		}
+	}
+
+	return pieces
+}
@@ -0,0 +1,70 @@
+package geometry
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func countCoveredByRasterizing(segments []Segment, threshold int) int {
+	counts := make(map[Coordinate]int)
+	for _, segment := range segments {
+		for _, point := range segment.Rasterize() {
+			counts[point]++
+		}
+	}
+
+	covered := 0
+	for _, count := range counts {
+		if count >= threshold {
+			covered++
+		}
+	}
+	return covered
+}
+
+func TestCountCovered_MatchesRasterizing(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	directions := []Coordinate{{1, 0}, {0, 1}, {1, 1}, {1, -1}, {-1, 0}, {-1, 1}}
+
+	for round := 0; round < 50; round++ {
+		var segments []Segment
+		for index := 0; index < 40; index++ {
+			start := NewCoordinate(random.Intn(30), random.Intn(30))
+			end := start.Add(directions[random.Intn(len(directions))].Scale(random.Intn(15)))
+			segments = append(segments, NewSegment(start, end))
+		}
+
+		for threshold := 1; threshold <= 4; threshold++ {
+			expected := countCoveredByRasterizing(segments, threshold)
+			actual, err := CountCovered(segments, threshold)
+			if err != nil || actual != expected {
+				t.Logf("Round %v, threshold %v: expected %v, got %v (%v)", round, threshold, expected, actual, err)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestCountCovered_LongSegments(t *testing.T) {
+	segments := []Segment{
+		NewSegment(NewCoordinate(0, 0), NewCoordinate(10_000_000, 0)),
+		NewSegment(NewCoordinate(5_000_000, 0), NewCoordinate(20_000_000, 0)),
+		NewSegment(NewCoordinate(7_000_000, -3), NewCoordinate(7_000_000, 3)),
+		NewSegment(NewCoordinate(0, 0), NewCoordinate(4_000_000, 4_000_000)),
+	}
+
+	for threshold, expected := range map[int]int{2: 5_000_002, 3: 1} {
+		actual, err := CountCovered(segments, threshold)
+		if err != nil || actual != expected {
+			t.Logf("Threshold %v: expected %v, got %v (%v)", threshold, expected, actual, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestCountCovered_RejectsOtherAngles(t *testing.T) {
+	if _, err := CountCovered([]Segment{NewSegment(NewCoordinate(0, 0), NewCoordinate(2, 1))}, 2); err == nil {
+		t.Log("Expected an error for a segment that is not at 45 degrees")
+		t.Fail()
+	}
+}
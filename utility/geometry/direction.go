@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Direction is a compass heading, with the eight of them running clockwise from North in 45 degree steps.
+// North is up the screen, towards smaller Y, matching the way puzzle input is laid out.
+
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+const directionCount = 8
+
+var CardinalDirections = []Direction{North, East, South, West}
+
+var AllDirections = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+var directionNames = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+var directionDeltas = [...]Coordinate{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+var directionAliases = map[string]Direction{
+	"u": North, "up": North, "north": North,
+	"r": East, "right": East, "east": East,
+	"d": South, "down": South, "south": South,
+	"l": West, "left": West, "west": West,
+	"northeast": NorthEast, "southeast": SouthEast, "southwest": SouthWest, "northwest": NorthWest,
+}
+
+// ParseDirection accepts compass letters (N, NE, ...), U/D/L/R, or the words for either, in any case.
+func ParseDirection(text string) (Direction, error) {
+	normalized := strings.ToLower(strings.TrimSpace(text))
+
+	for direction, name := range directionNames {
+		if normalized == strings.ToLower(name) {
+			return Direction(direction), nil
+		}
+	}
+
+	if direction, found := directionAliases[normalized]; found {
+		return direction, nil
+	}
+
+	return North, fmt.Errorf("unknown direction '%v'", text)
+}
+
+// Delta is the single step taken when moving in the direction.
+func (d Direction) Delta() Coordinate {
+	return directionDeltas[d]
+}
+
+func (d Direction) IsDiagonal() bool {
+	return d%2 == 1
+}
+
+func (d Direction) Reverse() Direction {
+	return d.Turn(directionCount / 2)
+}
+
+func (d Direction) String() string {
+	return directionNames[d]
+}
+
+// Turn rotates clockwise by steps of 45 degrees; negative steps turn anticlockwise.
+func (d Direction) Turn(steps int) Direction {
+	return Direction(((int(d)+steps)%directionCount + directionCount) % directionCount)
+}
+
+func (d Direction) TurnLeft() Direction {
+	return d.Turn(-2)
+}
+
+func (d Direction) TurnRight() Direction {
+	return d.Turn(2)
+}
@@ -0,0 +1,71 @@
+package geometry
+
+import "testing"
+
+func TestParseDirection(t *testing.T) {
+	for text, expected := range map[string]Direction{
+		"N":     North,
+		"ne":    NorthEast,
+		"U":     North,
+		"down":  South,
+		"L":     West,
+		"Right": East,
+		" sw ":  SouthWest,
+	} {
+		direction, err := ParseDirection(text)
+		if err != nil || direction != expected {
+			t.Logf("'%v': expected %v, got %v (%v)", text, expected, direction, err)
+			t.Fail()
+		}
+	}
+
+	if _, err := ParseDirection("forward"); err == nil {
+		t.Log("Expected an error for an unknown direction")
+		t.Fail()
+	}
+}
+
+func TestDirection_Turn(t *testing.T) {
+	if North.TurnRight() != East || North.TurnLeft() != West || West.TurnRight() != North {
+		t.Log("Expected quarter turns to step between the cardinal directions")
+		t.Fail()
+	}
+
+	if NorthEast.Reverse() != SouthWest || South.Turn(-1) != SouthEast || North.Turn(17) != NorthEast {
+		t.Log("Expected Turn to wrap around in 45 degree steps")
+		t.Fail()
+	}
+
+	for _, direction := range AllDirections {
+		if direction.Delta().Rotate(1) != direction.TurnRight().Delta() {
+			t.Logf("%v: expected turning right to match rotating the delta clockwise", direction)
+			t.Fail()
+		}
+	}
+}
+
+func TestTurtle(t *testing.T) {
+	turtle := NewTurtle(NewCoordinate(0, 0), East)
+	turtle.Run([]TurtleCommand{
+		func(turtle *Turtle) { turtle.Forward(3) },
+		func(turtle *Turtle) { turtle.TurnRight() },
+		func(turtle *Turtle) { turtle.Forward(2) },
+		func(turtle *Turtle) { turtle.Move(NorthWest, 1) },
+	})
+
+	if turtle.Position != NewCoordinate(2, 1) || turtle.Heading != South {
+		t.Logf("Expected { 2, 1 } heading S, got %v heading %v", turtle.Position, turtle.Heading)
+		t.Fail()
+	}
+}
+
+func TestTurtle_ForwardWithAim(t *testing.T) {
+	turtle := NewTurtle(NewCoordinate(0, 0), East)
+	turtle.AdjustAim(5)
+	turtle.Forward(8)
+
+	if turtle.Position != NewCoordinate(8, 40) {
+		t.Logf("Expected { 8, 40 }, got %v", turtle.Position)
+		t.Fail()
+	}
+}
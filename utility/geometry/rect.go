@@ -0,0 +1,137 @@
+package geometry
+
+import "fmt"
+
+// Rect is the axis-aligned rectangle from Min to Max, both corners included.
+// A Rect with Min beyond Max on either axis is empty; EmptyRect is the canonical one, and the identity for Union.
+
+type Rect struct {
+	Min, Max Coordinate
+}
+
+var EmptyRect = Rect{Min: Coordinate{X: 0, Y: 0}, Max: Coordinate{X: -1, Y: -1}}
+
+func NewRect(min, max Coordinate) Rect {
+	return Rect{Min: min, Max: max}
+}
+
+// RectFromSize returns the width by height Rect with its top left corner at origin.
+func RectFromSize(origin Coordinate, width, height int) Rect {
+	return Rect{Min: origin, Max: NewCoordinate(origin.X+width-1, origin.Y+height-1)}
+}
+
+// BoundingRect returns the smallest Rect containing every one of the coordinates.
+func BoundingRect(coordinates []Coordinate) Rect {
+	bounds := EmptyRect
+	for _, coordinate := range coordinates {
+		bounds = bounds.Extend(coordinate)
+	}
+	return bounds
+}
+
+func (r Rect) Area() int {
+	return r.Width() * r.Height()
+}
+
+func (r Rect) Contains(coordinate Coordinate) bool {
+	return coordinate.X >= r.Min.X && coordinate.X <= r.Max.X && coordinate.Y >= r.Min.Y && coordinate.Y <= r.Max.Y
+}
+
+func (r Rect) ContainsRect(other Rect) bool {
+	return other.IsEmpty() || (r.Contains(other.Min) && r.Contains(other.Max))
+}
+
+// Coordinates lists every contained coordinate, top to bottom then left to right.
+func (r Rect) Coordinates() []Coordinate {
+	coordinates := make([]Coordinate, 0, r.Area())
+	r.ForEach(func(coordinate Coordinate) {
+		coordinates = append(coordinates, coordinate)
+	})
+	return coordinates
+}
+
+// Expand grows the Rect by amount on every side; a negative amount shrinks it.
+func (r Rect) Expand(amount int) Rect {
+	if r.IsEmpty() {
+		return r
+	}
+	return Rect{
+		Min: r.Min.Sub(NewCoordinate(amount, amount)),
+		Max: r.Max.Add(NewCoordinate(amount, amount)),
+	}
+}
+
+// Extend returns the smallest Rect containing both r and the coordinate.
+func (r Rect) Extend(coordinate Coordinate) Rect {
+	return r.Union(Rect{Min: coordinate, Max: coordinate})
+}
+
+// ForEach visits every contained coordinate, top to bottom then left to right.
+func (r Rect) ForEach(visit func(coordinate Coordinate)) {
+	for y := r.Min.Y; y <= r.Max.Y; y++ {
+		for x := r.Min.X; x <= r.Max.X; x++ {
+			visit(NewCoordinate(x, y))
+		}
+	}
+}
+
+func (r Rect) Height() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.Max.Y - r.Min.Y + 1
+}
+
+func (r Rect) Intersect(other Rect) Rect {
+	intersection := Rect{
+		Min: NewCoordinate(max(r.Min.X, other.Min.X), max(r.Min.Y, other.Min.Y)),
+		Max: NewCoordinate(min(r.Max.X, other.Max.X), min(r.Max.Y, other.Max.Y)),
+	}
+	if intersection.IsEmpty() {
+		return EmptyRect
+	}
+	return intersection
+}
+
+func (r Rect) IsEmpty() bool {
+	return r.Min.X > r.Max.X || r.Min.Y > r.Max.Y
+}
+
+func (r Rect) Overlaps(other Rect) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+func (r Rect) String() string {
+	if r.IsEmpty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%v..%v]", r.Min, r.Max)
+}
+
+// Union returns the smallest Rect containing both, which may cover coordinates in neither.
+func (r Rect) Union(other Rect) Rect {
+	if r.IsEmpty() {
+		return other
+	}
+	if other.IsEmpty() {
+		return r
+	}
+	return Rect{
+		Min: NewCoordinate(min(r.Min.X, other.Min.X), min(r.Min.Y, other.Min.Y)),
+		Max: NewCoordinate(max(r.Max.X, other.Max.X), max(r.Max.Y, other.Max.Y)),
+	}
+}
+
+func (r Rect) Width() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.Max.X - r.Min.X + 1
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
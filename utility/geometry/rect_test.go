@@ -0,0 +1,86 @@
+package geometry
+
+import "testing"
+
+func TestBoundingRect(t *testing.T) {
+	bounds := BoundingRect([]Coordinate{{3, 7}, {-2, 4}, {5, 5}})
+	if bounds != NewRect(NewCoordinate(-2, 4), NewCoordinate(5, 7)) {
+		t.Logf("Expected [{ -2, 4 }..{ 5, 7 }], got %v", bounds)
+		t.Fail()
+	}
+
+	if bounds.Width() != 8 || bounds.Height() != 4 || bounds.Area() != 32 {
+		t.Logf("Expected 8 by 4, got %v by %v", bounds.Width(), bounds.Height())
+		t.Fail()
+	}
+
+	if !BoundingRect(nil).IsEmpty() || BoundingRect(nil).Area() != 0 {
+		t.Log("Expected no coordinates to give an empty Rect")
+		t.Fail()
+	}
+}
+
+func TestRect_Contains(t *testing.T) {
+	// Day 17's example target area, x=20..30, y=-10..-5.
+	target := NewRect(NewCoordinate(20, -10), NewCoordinate(30, -5))
+
+	for coordinate, expected := range map[Coordinate]bool{
+		{20, -10}: true,
+		{30, -5}:  true,
+		{28, -7}:  true,
+		{31, -7}:  false,
+		{25, -4}:  false,
+	} {
+		if target.Contains(coordinate) != expected {
+			t.Logf("Expected Contains(%v) to be %v", coordinate, expected)
+			t.Fail()
+		}
+	}
+
+	if !target.ContainsRect(RectFromSize(NewCoordinate(21, -9), 3, 3)) || target.ContainsRect(target.Expand(1)) {
+		t.Log("Expected ContainsRect to hold only for rectangles inside the target")
+		t.Fail()
+	}
+}
+
+func TestRect_IntersectAndUnion(t *testing.T) {
+	a := NewRect(NewCoordinate(0, 0), NewCoordinate(4, 4))
+	b := NewRect(NewCoordinate(3, 2), NewCoordinate(8, 3))
+
+	if intersection := a.Intersect(b); intersection != NewRect(NewCoordinate(3, 2), NewCoordinate(4, 3)) {
+		t.Logf("Expected [{ 3, 2 }..{ 4, 3 }], got %v", intersection)
+		t.Fail()
+	}
+
+	if union := a.Union(b); union != NewRect(NewCoordinate(0, 0), NewCoordinate(8, 4)) {
+		t.Logf("Expected [{ 0, 0 }..{ 8, 4 }], got %v", union)
+		t.Fail()
+	}
+
+	far := NewRect(NewCoordinate(10, 10), NewCoordinate(12, 12))
+	if a.Overlaps(far) || !a.Intersect(far).IsEmpty() {
+		t.Log("Expected separate rectangles not to overlap")
+		t.Fail()
+	}
+
+	if EmptyRect.Union(far) != far {
+		t.Log("Expected EmptyRect to leave a union unchanged")
+		t.Fail()
+	}
+}
+
+func TestRect_ExpandAndForEach(t *testing.T) {
+	single := NewRect(NewCoordinate(1, 1), NewCoordinate(1, 1))
+	expanded := single.Expand(1)
+
+	coordinates := expanded.Coordinates()
+	if len(coordinates) != 9 || coordinates[0] != NewCoordinate(0, 0) || coordinates[8] != NewCoordinate(2, 2) {
+		t.Logf("Expected the nine coordinates from { 0, 0 } to { 2, 2 }, got %v", coordinates)
+		t.Fail()
+	}
+
+	if !expanded.Expand(-2).IsEmpty() {
+		t.Log("Expected shrinking past the centre to give an empty Rect")
+		t.Fail()
+	}
+}
@@ -0,0 +1,145 @@
+package geometry
+
+// Rotation3 is one of the 24 ways to turn a cube so its faces stay lined up with the axes.
+// Every row and column of the matrix holds a single 1 or -1, and the determinant is 1, so nothing is ever mirrored.
+
+type Rotation3 struct {
+	matrix [3][3]int
+}
+
+// Transform3 rotates a coordinate and then translates it.
+type Transform3 struct {
+	Rotation    Rotation3
+	Translation Coordinate3
+}
+
+var rotations3 = generateRotations3()
+
+func IdentityRotation3() Rotation3 {
+	return Rotation3{matrix: [3][3]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}}
+}
+
+// Rotations3 returns all 24 rotations, starting with the identity, always in the same order.
+func Rotations3() []Rotation3 {
+	rotations := make([]Rotation3, len(rotations3))
+	copy(rotations, rotations3)
+	return rotations
+}
+
+func (r Rotation3) Apply(coordinate Coordinate3) Coordinate3 {
+	components := coordinate.components()
+	var rotated [3]int
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			rotated[row] += r.matrix[row][column] * components[column]
+		}
+	}
+	return NewCoordinate3(rotated[0], rotated[1], rotated[2])
+}
+
+// Compose returns the rotation that applies other first and then r.
+func (r Rotation3) Compose(other Rotation3) Rotation3 {
+	var composed Rotation3
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			for index := 0; index < 3; index++ {
+				composed.matrix[row][column] += r.matrix[row][index] * other.matrix[index][column]
+			}
+		}
+	}
+	return composed
+}
+
+// Inverse is the transpose, as it is for any rotation matrix.
+func (r Rotation3) Inverse() Rotation3 {
+	var inverse Rotation3
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			inverse.matrix[column][row] = r.matrix[row][column]
+		}
+	}
+	return inverse
+}
+
+func NewTransform3(rotation Rotation3, translation Coordinate3) Transform3 {
+	return Transform3{Rotation: rotation, Translation: translation}
+}
+
+func IdentityTransform3() Transform3 {
+	return Transform3{Rotation: IdentityRotation3()}
+}
+
+func (t Transform3) Apply(coordinate Coordinate3) Coordinate3 {
+	return t.Rotation.Apply(coordinate).Add(t.Translation)
+}
+
+// Compose returns the transform that applies other first and then t.
+func (t Transform3) Compose(other Transform3) Transform3 {
+	return Transform3{
+		Rotation:    t.Rotation.Compose(other.Rotation),
+		Translation: t.Apply(other.Translation),
+	}
+}
+
+func (t Transform3) Inverse() Transform3 {
+	inverse := t.Rotation.Inverse()
+	return Transform3{
+		Rotation:    inverse,
+		Translation: inverse.Apply(t.Translation).Neg(),
+	}
+}
+
+// Align looks for the transform that moves at least overlap of candidate's points onto points in reference.
+// The clouds are expected to hold distinct points; the first rotation and translation found to line up is returned.
+func Align(reference, candidate []Coordinate3, overlap int) (Transform3, bool) {
+	for _, rotation := range rotations3 {
+		rotated := make([]Coordinate3, len(candidate))
+		for index, coordinate := range candidate {
+			rotated[index] = rotation.Apply(coordinate)
+		}
+
+		votes := make(map[Coordinate3]int)
+		for _, anchor := range reference {
+			for _, coordinate := range rotated {
+				translation := anchor.Sub(coordinate)
+				votes[translation]++
+				if votes[translation] >= overlap {
+					return NewTransform3(rotation, translation), true
+				}
+			}
+		}
+	}
+
+	return Transform3{}, false
+}
+
+// generateRotations3
+// Choosing where X and Y end up, as any axis in either direction, fixes Z as their cross product.
+func generateRotations3() []Rotation3 {
+	axes := []Coordinate3{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+
+	var rotations []Rotation3
+	for _, x := range axes {
+		for _, y := range axes {
+			if x.Add(y) == (Coordinate3{}) || x == y {
+				continue
+			}
+
+			z := Coordinate3{
+				X: x.Y*y.Z - x.Z*y.Y,
+				Y: x.Z*y.X - x.X*y.Z,
+				Z: x.X*y.Y - x.Y*y.X,
+			}
+
+			var rotation Rotation3
+			for column, axis := range [3]Coordinate3{x, y, z} {
+				for row, value := range axis.components() {
+					rotation.matrix[row][column] = value
+				}
+			}
+			rotations = append(rotations, rotation)
+		}
+	}
+
+	return rotations
+}
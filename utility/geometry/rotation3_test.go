@@ -0,0 +1,115 @@
+package geometry
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRotations3(t *testing.T) {
+	rotations := Rotations3()
+	if len(rotations) != 24 {
+		t.Logf("Expected 24 rotations, got %v", len(rotations))
+		t.FailNow()
+	}
+
+	if rotations[0] != IdentityRotation3() {
+		t.Log("Expected the identity first")
+		t.Fail()
+	}
+
+	point := NewCoordinate3(1, 2, 3)
+	images := make(map[Coordinate3]bool)
+	for _, rotation := range rotations {
+		images[rotation.Apply(point)] = true
+
+		if rotation.Compose(rotation.Inverse()) != IdentityRotation3() {
+			t.Logf("Expected %v composed with its inverse to be the identity", rotation)
+			t.Fail()
+		}
+	}
+
+	if len(images) != 24 {
+		t.Logf("Expected 24 distinct images of %v, got %v", point, len(images))
+		t.Fail()
+	}
+}
+
+func TestRotations3_ClosedUnderComposition(t *testing.T) {
+	known := make(map[Rotation3]bool)
+	for _, rotation := range Rotations3() {
+		known[rotation] = true
+	}
+
+	for _, first := range Rotations3() {
+		for _, second := range Rotations3() {
+			if !known[first.Compose(second)] {
+				t.Logf("Expected %v composed with %v to be one of the 24 rotations", first, second)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestTransform3_ComposeAndInverse(t *testing.T) {
+	rotations := Rotations3()
+	first := NewTransform3(rotations[5], NewCoordinate3(10, -4, 7))
+	second := NewTransform3(rotations[17], NewCoordinate3(-3, 8, 1))
+	point := NewCoordinate3(4, 5, -6)
+
+	if first.Compose(second).Apply(point) != first.Apply(second.Apply(point)) {
+		t.Log("Expected a composed transform to apply its parts in turn")
+		t.Fail()
+	}
+
+	if first.Inverse().Apply(first.Apply(point)) != point {
+		t.Log("Expected the inverse to undo the transform")
+		t.Fail()
+	}
+}
+
+func TestAlign(t *testing.T) {
+	random := rand.New(rand.NewSource(2021))
+	randomPoint := func() Coordinate3 {
+		return NewCoordinate3(random.Intn(2001)-1000, random.Intn(2001)-1000, random.Intn(2001)-1000)
+	}
+
+	for _, rotation := range Rotations3() {
+		shared := make([]Coordinate3, 12)
+		for index := range shared {
+			shared[index] = randomPoint()
+		}
+
+		// The candidate sees the shared points, plus a few of its own, from somewhere else and facing another way.
+		hidden := NewTransform3(rotation, randomPoint())
+		var reference, candidate []Coordinate3
+		for _, point := range shared {
+			reference = append(reference, point)
+			candidate = append(candidate, hidden.Inverse().Apply(point))
+		}
+		for index := 0; index < 10; index++ {
+			reference = append(reference, randomPoint())
+			candidate = append(candidate, randomPoint())
+		}
+
+		transform, found := Align(reference, candidate, 12)
+		if !found {
+			t.Logf("Expected to align clouds rotated by %v", rotation)
+			t.FailNow()
+		}
+
+		if transform != hidden {
+			t.Logf("Expected %v, got %v", hidden, transform)
+			t.Fail()
+		}
+	}
+}
+
+func TestAlign_NotEnoughOverlap(t *testing.T) {
+	reference := []Coordinate3{{0, 0, 0}, {1, 0, 0}, {0, 5, 0}}
+	candidate := []Coordinate3{{10, 10, 10}, {11, 10, 10}}
+
+	if _, found := Align(reference, candidate, 3); found {
+		t.Log("Expected no alignment with only two points to share")
+		t.Fail()
+	}
+}
@@ -0,0 +1,194 @@
+package geometry
+
+import "fmt"
+
+// Segment is the straight line between two coordinates, including both ends.
+// Rasterize gives every cell a line drawn between them would touch, while LatticePoints only gives the coordinates
+// the line passes exactly through; the two agree for horizontal, vertical and 45 degree segments.
+
+type Segment struct {
+	Start, End Coordinate
+}
+
+func NewSegment(start, end Coordinate) Segment {
+	return Segment{Start: start, End: end}
+}
+
+// Contains reports whether the coordinate lies exactly on the segment.
+func (s Segment) Contains(coordinate Coordinate) bool {
+	if cross(s.Delta(), coordinate.Sub(s.Start)) != 0 {
+		return false
+	}
+
+	return between(coordinate.X, s.Start.X, s.End.X) && between(coordinate.Y, s.Start.Y, s.End.Y)
+}
+
+func (s Segment) Delta() Coordinate {
+	return s.End.Sub(s.Start)
+}
+
+// Intersects reports whether the two segments share any point at all, whether or not it is a lattice point.
+func (s Segment) Intersects(other Segment) bool {
+	first := orientation(s.Start, s.End, other.Start)
+	second := orientation(s.Start, s.End, other.End)
+	third := orientation(other.Start, other.End, s.Start)
+	fourth := orientation(other.Start, other.End, s.End)
+
+	if first != second && third != fourth {
+		return true
+	}
+
+	return (first == 0 && s.Contains(other.Start)) ||
+		(second == 0 && s.Contains(other.End)) ||
+		(third == 0 && other.Contains(s.Start)) ||
+		(fourth == 0 && other.Contains(s.End))
+}
+
+// IsDiagonal is true only for segments at exactly 45 degrees.
+func (s Segment) IsDiagonal() bool {
+	delta := s.Delta()
+	return delta.X != 0 && abs(delta.X) == abs(delta.Y)
+}
+
+func (s Segment) IsHorizontal() bool {
+	return s.Start.Y == s.End.Y
+}
+
+func (s Segment) IsVertical() bool {
+	return s.Start.X == s.End.X
+}
+
+// LatticePoints walks from Start to End in the smallest whole-number step that lands exactly on the line.
+func (s Segment) LatticePoints() []Coordinate {
+	delta := s.Delta()
+	steps := gcd(abs(delta.X), abs(delta.Y))
+	if steps == 0 {
+		return []Coordinate{s.Start}
+	}
+
+	step := NewCoordinate(delta.X/steps, delta.Y/steps)
+	points := make([]Coordinate, 0, steps+1)
+	for point, index := s.Start, 0; index <= steps; point, index = point.Add(step), index+1 {
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// Overlap returns the part two collinear segments have in common, which may be a single point.
+// Segments that cross, rather than run along one another, do not overlap.
+func (s Segment) Overlap(other Segment) (Segment, bool) {
+	direction := s.Delta()
+	if direction == (Coordinate{}) {
+		direction = other.Delta()
+	}
+
+	if cross(direction, other.Start.Sub(s.Start)) != 0 || cross(direction, other.End.Sub(s.Start)) != 0 {
+		return Segment{}, false
+	}
+
+	first, second := s.ordered(), other.ordered()
+
+	start := first.Start
+	if start.Less(second.Start) {
+		start = second.Start
+	}
+
+	end := first.End
+	if second.End.Less(end) {
+		end = second.End
+	}
+
+	if end.Less(start) {
+		return Segment{}, false
+	}
+
+	return NewSegment(start, end), true
+}
+
+// Rasterize uses Bresenham's algorithm, giving one coordinate per step along whichever axis the segment is longer in.
+func (s Segment) Rasterize() []Coordinate {
+	delta := s.Delta()
+	step := delta.Sign()
+	deltaX, deltaY := abs(delta.X), -abs(delta.Y)
+	err := deltaX + deltaY
+
+	points := make([]Coordinate, 0, s.Start.Chebyshev(s.End)+1)
+	for point := s.Start; ; {
+		points = append(points, point)
+		if point == s.End {
+			break
+		}
+
+		doubled := 2 * err
+		if doubled >= deltaY {
+			err += deltaY
+			point.X += step.X
+		}
+		if doubled <= deltaX {
+			err += deltaX
+			point.Y += step.Y
+		}
+	}
+
+	return points
+}
+
+// SharedLatticePoints returns the lattice points that lie on both segments.
+func (s Segment) SharedLatticePoints(other Segment) []Coordinate {
+	if overlap, found := s.Overlap(other); found {
+		return overlap.LatticePoints()
+	}
+
+	direction, otherDirection := s.Delta(), other.Delta()
+	denominator := cross(direction, otherDirection)
+	if denominator == 0 {
+		return nil
+	}
+
+	numerator := cross(other.Start.Sub(s.Start), otherDirection)
+	if (direction.X*numerator)%denominator != 0 || (direction.Y*numerator)%denominator != 0 {
+		return nil
+	}
+
+	point := s.Start.Add(NewCoordinate(direction.X*numerator/denominator, direction.Y*numerator/denominator))
+	if !s.Contains(point) || !other.Contains(point) {
+		return nil
+	}
+
+	return []Coordinate{point}
+}
+
+func (s Segment) String() string {
+	return fmt.Sprintf("%v -> %v", s.Start, s.End)
+}
+
+// ordered swaps the ends, if need be, so Start comes before End.
+func (s Segment) ordered() Segment {
+	if s.End.Less(s.Start) {
+		return NewSegment(s.End, s.Start)
+	}
+	return s
+}
+
+func between(value, a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return value >= a && value <= b
+}
+
+func cross(a, b Coordinate) int {
+	return a.X*b.Y - a.Y*b.X
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func orientation(a, b, c Coordinate) int {
+	return sign(cross(b.Sub(a), c.Sub(a)))
+}
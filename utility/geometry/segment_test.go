@@ -0,0 +1,123 @@
+package geometry
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSegment_RasterizeMatchesLatticePointsOnStraightLines(t *testing.T) {
+	for _, segment := range []Segment{
+		NewSegment(NewCoordinate(0, 9), NewCoordinate(5, 9)),
+		NewSegment(NewCoordinate(7, 4), NewCoordinate(7, 0)),
+		NewSegment(NewCoordinate(8, 0), NewCoordinate(0, 8)),
+		NewSegment(NewCoordinate(6, 4), NewCoordinate(2, 0)),
+		NewSegment(NewCoordinate(3, 3), NewCoordinate(3, 3)),
+	} {
+		rasterized := fmt.Sprint(segment.Rasterize())
+		lattice := fmt.Sprint(segment.LatticePoints())
+		if rasterized != lattice {
+			t.Logf("%v: expected Rasterize %v to match LatticePoints %v", segment, rasterized, lattice)
+			t.Fail()
+		}
+	}
+}
+
+func TestSegment_RasterizeAnySlope(t *testing.T) {
+	for _, segment := range []Segment{
+		NewSegment(NewCoordinate(0, 0), NewCoordinate(6, 2)),
+		NewSegment(NewCoordinate(6, 2), NewCoordinate(0, 0)),
+		NewSegment(NewCoordinate(1, 8), NewCoordinate(4, -3)),
+		NewSegment(NewCoordinate(-5, 1), NewCoordinate(2, 3)),
+	} {
+		points := segment.Rasterize()
+		if points[0] != segment.Start || points[len(points)-1] != segment.End {
+			t.Logf("%v: expected to run from end to end, got %v", segment, points)
+			t.Fail()
+		}
+
+		if len(points) != segment.Start.Chebyshev(segment.End)+1 {
+			t.Logf("%v: expected %v points, got %v", segment, segment.Start.Chebyshev(segment.End)+1, len(points))
+			t.Fail()
+		}
+
+		for index := 1; index < len(points); index++ {
+			if points[index].Chebyshev(points[index-1]) != 1 {
+				t.Logf("%v: expected each point to touch the last, got %v", segment, points)
+				t.Fail()
+				break
+			}
+		}
+	}
+}
+
+func TestSegment_LatticePoints(t *testing.T) {
+	points := NewSegment(NewCoordinate(0, 0), NewCoordinate(6, 4)).LatticePoints()
+	expected := []Coordinate{{0, 0}, {3, 2}, {6, 4}}
+
+	if fmt.Sprint(points) != fmt.Sprint(expected) {
+		t.Logf("Expected %v, got %v", expected, points)
+		t.Fail()
+	}
+
+	if len(NewSegment(NewCoordinate(0, 0), NewCoordinate(5, 3)).LatticePoints()) != 2 {
+		t.Log("Expected only the ends of a segment with coprime deltas")
+		t.Fail()
+	}
+}
+
+func TestSegment_Intersects(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     Segment
+		expected bool
+	}{
+		{NewSegment(Coordinate{0, 0}, Coordinate{4, 4}), NewSegment(Coordinate{0, 4}, Coordinate{4, 0}), true},
+		{NewSegment(Coordinate{0, 0}, Coordinate{3, 1}), NewSegment(Coordinate{0, 1}, Coordinate{3, 0}), true},
+		{NewSegment(Coordinate{0, 0}, Coordinate{4, 0}), NewSegment(Coordinate{4, 0}, Coordinate{4, 3}), true},
+		{NewSegment(Coordinate{0, 0}, Coordinate{4, 0}), NewSegment(Coordinate{5, 0}, Coordinate{9, 0}), false},
+		{NewSegment(Coordinate{0, 0}, Coordinate{4, 0}), NewSegment(Coordinate{0, 1}, Coordinate{4, 1}), false},
+	} {
+		if testCase.a.Intersects(testCase.b) != testCase.expected {
+			t.Logf("%v and %v: expected %v", testCase.a, testCase.b, testCase.expected)
+			t.Fail()
+		}
+	}
+}
+
+func TestSegment_Overlap(t *testing.T) {
+	a := NewSegment(NewCoordinate(0, 0), NewCoordinate(6, 6))
+	b := NewSegment(NewCoordinate(9, 9), NewCoordinate(4, 4))
+
+	overlap, found := a.Overlap(b)
+	if !found || overlap != NewSegment(NewCoordinate(4, 4), NewCoordinate(6, 6)) {
+		t.Logf("Expected {4, 4} -> {6, 6}, got %v", overlap)
+		t.Fail()
+	}
+
+	if _, found := a.Overlap(NewSegment(NewCoordinate(0, 6), NewCoordinate(6, 0))); found {
+		t.Log("Expected crossing segments not to overlap")
+		t.Fail()
+	}
+
+	if _, found := a.Overlap(NewSegment(NewCoordinate(7, 7), NewCoordinate(9, 9))); found {
+		t.Log("Expected collinear but separate segments not to overlap")
+		t.Fail()
+	}
+}
+
+func TestSegment_SharedLatticePoints(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     Segment
+		expected []Coordinate
+	}{
+		{NewSegment(Coordinate{0, 0}, Coordinate{4, 4}), NewSegment(Coordinate{0, 4}, Coordinate{4, 0}), []Coordinate{{2, 2}}},
+		{NewSegment(Coordinate{0, 0}, Coordinate{3, 1}), NewSegment(Coordinate{0, 1}, Coordinate{3, 0}), nil},
+		{NewSegment(Coordinate{0, 0}, Coordinate{8, 0}), NewSegment(Coordinate{6, 0}, Coordinate{12, 0}), []Coordinate{{6, 0}, {7, 0}, {8, 0}}},
+		{NewSegment(Coordinate{0, 0}, Coordinate{2, 2}), NewSegment(Coordinate{3, 0}, Coordinate{3, 5}), nil},
+	} {
+		actual := testCase.a.SharedLatticePoints(testCase.b)
+		if fmt.Sprint(actual) != fmt.Sprint(testCase.expected) {
+			t.Logf("%v and %v: expected %v, got %v", testCase.a, testCase.b, testCase.expected, actual)
+			t.Fail()
+		}
+	}
+}
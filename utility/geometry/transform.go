@@ -0,0 +1,85 @@
+package geometry
+
+// Transform maps a coordinate through a 2x2 integer matrix and then adds a translation.
+// The constructors only make rotations, reflections and translations, so every Transform can be undone and maps
+// a Rect onto another Rect.
+
+type Transform struct {
+	matrix      [2][2]int
+	translation Coordinate
+}
+
+func IdentityTransform() Transform {
+	return Transform{matrix: [2][2]int{{1, 0}, {0, 1}}}
+}
+
+// NewReflectionX mirrors across the vertical line x=at.
+func NewReflectionX(at int) Transform {
+	return Transform{matrix: [2][2]int{{-1, 0}, {0, 1}}, translation: NewCoordinate(2*at, 0)}
+}
+
+// NewReflectionY mirrors across the horizontal line y=at.
+func NewReflectionY(at int) Transform {
+	return Transform{matrix: [2][2]int{{1, 0}, {0, -1}}, translation: NewCoordinate(0, 2*at)}
+}
+
+// NewRotation turns about the origin by quarterTurns clockwise, as Coordinate.Rotate does.
+func NewRotation(quarterTurns int) Transform {
+	x, y := NewCoordinate(1, 0).Rotate(quarterTurns), NewCoordinate(0, 1).Rotate(quarterTurns)
+	return Transform{matrix: [2][2]int{{x.X, y.X}, {x.Y, y.Y}}}
+}
+
+func NewTranslation(delta Coordinate) Transform {
+	return Transform{matrix: IdentityTransform().matrix, translation: delta}
+}
+
+func (t Transform) Apply(coordinate Coordinate) Coordinate {
+	return NewCoordinate(
+		t.matrix[0][0]*coordinate.X+t.matrix[0][1]*coordinate.Y,
+		t.matrix[1][0]*coordinate.X+t.matrix[1][1]*coordinate.Y,
+	).Add(t.translation)
+}
+
+// ApplyRect returns the Rect the corners of r end up bounding.
+func (t Transform) ApplyRect(r Rect) Rect {
+	if r.IsEmpty() {
+		return r
+	}
+	return BoundingRect([]Coordinate{t.Apply(r.Min), t.Apply(r.Max)})
+}
+
+// Compose returns the transform that applies other first and then t.
+func (t Transform) Compose(other Transform) Transform {
+	var composed Transform
+	for row := 0; row < 2; row++ {
+		for column := 0; column < 2; column++ {
+			for index := 0; index < 2; index++ {
+				composed.matrix[row][column] += t.matrix[row][index] * other.matrix[index][column]
+			}
+		}
+	}
+	composed.translation = t.Apply(other.translation)
+	return composed
+}
+
+// Inverse relies on the determinant being 1 or -1, which holds for anything built from the constructors.
+func (t Transform) Inverse() Transform {
+	determinant := t.matrix[0][0]*t.matrix[1][1] - t.matrix[0][1]*t.matrix[1][0]
+
+	inverse := Transform{matrix: [2][2]int{
+		{t.matrix[1][1] * determinant, -t.matrix[0][1] * determinant},
+		{-t.matrix[1][0] * determinant, t.matrix[0][0] * determinant},
+	}}
+	inverse.translation = inverse.Apply(t.translation).Neg()
+
+	return inverse
+}
+
+func (t Transform) IsIdentity() bool {
+	return t == IdentityTransform()
+}
+
+// Then returns the transform that applies t first and then next, which reads more naturally when chaining.
+func (t Transform) Then(next Transform) Transform {
+	return next.Compose(t)
+}
@@ -0,0 +1,63 @@
+package geometry
+
+import "testing"
+
+func TestTransform_Constructors(t *testing.T) {
+	point := NewCoordinate(3, 7)
+
+	for _, testCase := range []struct {
+		name      string
+		transform Transform
+		expected  Coordinate
+	}{
+		{"identity", IdentityTransform(), point},
+		{"reflect x=5", NewReflectionX(5), point.ReflectX(5)},
+		{"reflect y=2", NewReflectionY(2), point.ReflectY(2)},
+		{"rotate 1", NewRotation(1), point.Rotate(1)},
+		{"rotate 2", NewRotation(2), point.Rotate(2)},
+		{"rotate -1", NewRotation(-1), point.Rotate(3)},
+		{"translate", NewTranslation(NewCoordinate(-3, 1)), NewCoordinate(0, 8)},
+	} {
+		if actual := testCase.transform.Apply(point); actual != testCase.expected {
+			t.Logf("%v: expected %v, got %v", testCase.name, testCase.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestTransform_ComposeAndInverse(t *testing.T) {
+	first := NewReflectionX(7)
+	second := NewRotation(1).Then(NewTranslation(NewCoordinate(2, -5)))
+	third := NewReflectionY(4)
+	point := NewCoordinate(-4, 9)
+
+	composed := first.Then(second).Then(third)
+	if composed.Apply(point) != third.Apply(second.Apply(first.Apply(point))) {
+		t.Log("Expected a composed transform to apply its parts in turn")
+		t.Fail()
+	}
+
+	if composed.Inverse().Apply(composed.Apply(point)) != point {
+		t.Log("Expected the inverse to undo the transform")
+		t.Fail()
+	}
+
+	if !NewReflectionX(3).Then(NewReflectionX(3)).IsIdentity() {
+		t.Log("Expected reflecting twice across the same line to be the identity")
+		t.Fail()
+	}
+}
+
+func TestTransform_ApplyRect(t *testing.T) {
+	r := NewRect(NewCoordinate(6, 0), NewCoordinate(10, 4))
+
+	if mirrored := NewReflectionX(5).ApplyRect(r); mirrored != NewRect(NewCoordinate(0, 0), NewCoordinate(4, 4)) {
+		t.Logf("Expected [{ 0, 0 }..{ 4, 4 }], got %v", mirrored)
+		t.Fail()
+	}
+
+	if rotated := NewRotation(1).ApplyRect(r); rotated != NewRect(NewCoordinate(-4, 6), NewCoordinate(0, 10)) {
+		t.Logf("Expected [{ -4, 6 }..{ 0, 10 }], got %v", rotated)
+		t.Fail()
+	}
+}
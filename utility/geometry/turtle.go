@@ -0,0 +1,49 @@
+package geometry
+
+// Turtle moves about the plane following commands, remembering where it is, which way it faces and its aim.
+// Aim tilts forward movement: every step forward also moves Aim steps to the turtle's right, so a turtle heading East
+// with a positive aim drifts South as it goes.
+
+type Turtle struct {
+	Position Coordinate
+	Heading  Direction
+	Aim      int
+}
+
+type TurtleCommand func(turtle *Turtle)
+
+func NewTurtle(position Coordinate, heading Direction) Turtle {
+	return Turtle{
+		Position: position,
+		Heading:  heading,
+	}
+}
+
+func (t *Turtle) AdjustAim(amount int) {
+	t.Aim += amount
+}
+
+func (t *Turtle) Forward(distance int) {
+	t.Position = t.Position.
+		Add(t.Heading.Delta().Scale(distance)).
+		Add(t.Heading.TurnRight().Delta().Scale(t.Aim * distance))
+}
+
+// Move steps in the given direction whatever the heading, ignoring aim.
+func (t *Turtle) Move(direction Direction, distance int) {
+	t.Position = t.Position.Add(direction.Delta().Scale(distance))
+}
+
+func (t *Turtle) Run(commands []TurtleCommand) {
+	for _, command := range commands {
+		command(t)
+	}
+}
+
+func (t *Turtle) TurnLeft() {
+	t.Heading = t.Heading.TurnLeft()
+}
+
+func (t *Turtle) TurnRight() {
+	t.Heading = t.Heading.TurnRight()
+}
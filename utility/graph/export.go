@@ -0,0 +1,316 @@
+package graph
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"advent-of-code-2021/utility/render"
+	"fmt"
+	"strings"
+)
+
+// ToDOT and ToMermaid write a graph as text for Graphviz and Mermaid respectively, and ToSVG draws it directly.
+// Output only depends on the order vertices and edges were added, so the same graph always exports identically.
+// Vertices are given generated identifiers, v0, v1, ..., and shown with their Label.
+
+type Shape int
+
+const (
+	ShapeEllipse Shape = iota
+	ShapeBox
+	ShapeCircle
+	ShapeDoubleCircle
+)
+
+type VertexStyle struct {
+	Shape     Shape
+	FillColor string
+}
+
+type ExportOptions[V comparable] struct {
+	Name        string
+	Label       func(vertex V) string
+	Style       func(vertex V) VertexStyle
+	Paths       [][]V
+	ShowWeights bool
+}
+
+// PathColors are given to highlighted paths in turn; an edge shared by several paths takes the colour of the first.
+var PathColors = []string{"#d62728", "#1f77b4", "#2ca02c", "#ff7f0e", "#9467bd", "#8c564b", "#e377c2", "#17becf"}
+
+type exportEdge[V comparable] struct {
+	edge      Edge[V]
+	pathIndex int
+}
+
+func (g *Graph[V]) ToDOT(options ExportOptions[V]) string {
+	ids := g.exportIds()
+	var builder strings.Builder
+
+	keyword, connector := "graph", "--"
+	if g.directed {
+		keyword, connector = "digraph", "->"
+	}
+
+	fmt.Fprintf(&builder, "%v %v {\n", keyword, dotQuote(options.name()))
+
+	for _, vertex := range g.vertices {
+		style := options.style(vertex)
+		attributes := []string{
+			"label=" + dotQuote(options.label(vertex)),
+			"shape=" + style.Shape.dotName(),
+		}
+		if len(style.FillColor) > 0 {
+			attributes = append(attributes, "style=filled", "fillcolor="+dotQuote(style.FillColor))
+		}
+		fmt.Fprintf(&builder, "  %v [%v];\n", ids[vertex], strings.Join(attributes, ", "))
+	}
+
+	for _, exported := range g.exportEdges(options.Paths) {
+		var attributes []string
+		if options.ShowWeights {
+			attributes = append(attributes, fmt.Sprintf("label=\"%v\"", exported.edge.Weight))
+		}
+		if exported.pathIndex >= 0 {
+			attributes = append(attributes, "color="+dotQuote(pathColor(exported.pathIndex)), "penwidth=3")
+		}
+
+		fmt.Fprintf(&builder, "  %v %v %v", ids[exported.edge.From], connector, ids[exported.edge.To])
+		if len(attributes) > 0 {
+			fmt.Fprintf(&builder, " [%v]", strings.Join(attributes, ", "))
+		}
+		builder.WriteString(";\n")
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func (g *Graph[V]) ToMermaid(options ExportOptions[V]) string {
+	ids := g.exportIds()
+	var builder strings.Builder
+
+	builder.WriteString("graph LR\n")
+	if len(options.Name) > 0 {
+		fmt.Fprintf(&builder, "  %%%% %v\n", options.Name)
+	}
+
+	for _, vertex := range g.vertices {
+		style := options.style(vertex)
+		opening, closing := style.Shape.mermaidBrackets()
+		fmt.Fprintf(&builder, "  %v%v%v%v\n", ids[vertex], opening, mermaidQuote(options.label(vertex)), closing)
+	}
+
+	connector := "---"
+	if g.directed {
+		connector = "-->"
+	}
+
+	var linkStyles []string
+	for index, exported := range g.exportEdges(options.Paths) {
+		if options.ShowWeights {
+			fmt.Fprintf(&builder, "  %v -- %v %v %v\n", ids[exported.edge.From], exported.edge.Weight, connector, ids[exported.edge.To])
+		} else {
+			fmt.Fprintf(&builder, "  %v %v %v\n", ids[exported.edge.From], connector, ids[exported.edge.To])
+		}
+
+		if exported.pathIndex >= 0 {
+			linkStyles = append(linkStyles, fmt.Sprintf("  linkStyle %v stroke:%v,stroke-width:3px\n", index, pathColor(exported.pathIndex)))
+		}
+	}
+
+	for _, vertex := range g.vertices {
+		if fill := options.style(vertex).FillColor; len(fill) > 0 {
+			fmt.Fprintf(&builder, "  style %v fill:%v\n", ids[vertex], fill)
+		}
+	}
+
+	for _, linkStyle := range linkStyles {
+		builder.WriteString(linkStyle)
+	}
+
+	return builder.String()
+}
+
+// ToSVG lays the graph out in columns by distance, in edges, from the first vertex added, starting a new set of columns
+// for each part of the graph that cannot be reached from those before it.
+// Every vertex is drawn as a box, whatever its Shape, and the direction of edges is not shown.
+func (g *Graph[V]) ToSVG(options ExportOptions[V], scale int) render.SVG {
+	labelWidth := 1
+	for _, vertex := range g.vertices {
+		if width := len(options.label(vertex)); width > labelWidth {
+			labelWidth = width
+		}
+	}
+	halfWidth := (labelWidth + 1) / 2
+
+	positions := g.svgLayout(2*halfWidth+4, 3)
+	var centres []geometry.Coordinate
+	for _, vertex := range g.vertices {
+		centres = append(centres, positions[vertex])
+	}
+	bounds, margin := geometry.BoundingRect(centres), geometry.NewCoordinate(halfWidth+1, 1)
+	svg := render.NewSVG(geometry.NewRect(bounds.Min.Sub(margin), bounds.Max.Add(margin)), scale)
+
+	for _, exported := range g.exportEdges(options.Paths) {
+		from, to := positions[exported.edge.From], positions[exported.edge.To]
+
+		style := render.Style{Stroke: "#000000", StrokeWidth: 0.1}
+		if exported.pathIndex >= 0 {
+			style = render.Style{Stroke: pathColor(exported.pathIndex), StrokeWidth: 0.3}
+		}
+		svg.Line(from, to, style)
+
+		if options.ShowWeights {
+			middle := geometry.NewCoordinate((from.X+to.X)/2, (from.Y+to.Y)/2)
+			svg.Text(middle, fmt.Sprint(exported.edge.Weight), render.Style{Fill: "#555555", FontSize: 0.6})
+		}
+	}
+
+	for _, vertex := range g.vertices {
+		centre := positions[vertex]
+		fill := options.style(vertex).FillColor
+		if len(fill) == 0 {
+			fill = "#ffffff"
+		}
+
+		box := geometry.NewRect(centre.Sub(geometry.NewCoordinate(halfWidth, 0)), centre.Add(geometry.NewCoordinate(halfWidth, 0)))
+		svg.Rect(box, render.Style{Fill: fill, Stroke: "#000000", StrokeWidth: 0.1})
+		svg.Text(centre, options.label(vertex), render.Style{})
+	}
+
+	return svg
+}
+
+func (g *Graph[V]) exportEdges(paths [][]V) []exportEdge[V] {
+	type step struct{ from, to V }
+	highlighted := make(map[step]int)
+
+	for pathIndex, path := range paths {
+		for index := 1; index < len(path); index++ {
+			forward := step{from: path[index-1], to: path[index]}
+			if _, found := highlighted[forward]; !found {
+				highlighted[forward] = pathIndex
+			}
+			if !g.directed {
+				backward := step{from: path[index], to: path[index-1]}
+				if _, found := highlighted[backward]; !found {
+					highlighted[backward] = pathIndex
+				}
+			}
+		}
+	}
+
+	var edges []exportEdge[V]
+	for _, edge := range g.Edges() {
+		pathIndex, found := highlighted[step{from: edge.From, to: edge.To}]
+		if !found {
+			pathIndex = -1
+		}
+		edges = append(edges, exportEdge[V]{edge: edge, pathIndex: pathIndex})
+	}
+
+	return edges
+}
+
+// svgLayout places each vertex at the centre of a cell, columnSpacing cells apart by distance and rowSpacing apart within
+// a column, in the order the vertices were added.
+func (g *Graph[V]) svgLayout(columnSpacing, rowSpacing int) map[V]geometry.Coordinate {
+	positions := make(map[V]geometry.Coordinate)
+	var rows []int
+
+	firstColumn := 0
+	for _, vertex := range g.vertices {
+		if _, placed := positions[vertex]; placed {
+			continue
+		}
+
+		lastColumn := firstColumn
+		g.BreadthFirst(vertex, func(reached V, depth int) bool {
+			if _, placed := positions[reached]; placed {
+				return true
+			}
+
+			column := firstColumn + depth
+			for len(rows) <= column {
+				rows = append(rows, 0)
+			}
+			positions[reached] = geometry.NewCoordinate(column*columnSpacing, rows[column]*rowSpacing)
+			rows[column]++
+
+			if column > lastColumn {
+				lastColumn = column
+			}
+			return true
+		})
+		firstColumn = lastColumn + 1
+	}
+
+	return positions
+}
+
+func (g *Graph[V]) exportIds() map[V]string {
+	ids := make(map[V]string)
+	for index, vertex := range g.vertices {
+		ids[vertex] = fmt.Sprintf("v%v", index)
+	}
+	return ids
+}
+
+func (o ExportOptions[V]) label(vertex V) string {
+	if o.Label == nil {
+		return fmt.Sprint(vertex)
+	}
+	return o.Label(vertex)
+}
+
+func (o ExportOptions[V]) name() string {
+	if len(o.Name) == 0 {
+		return "G"
+	}
+	return o.Name
+}
+
+func (o ExportOptions[V]) style(vertex V) VertexStyle {
+	if o.Style == nil {
+		return VertexStyle{}
+	}
+	return o.Style(vertex)
+}
+
+func (s Shape) dotName() string {
+	switch s {
+	case ShapeBox:
+		return "box"
+	case ShapeCircle:
+		return "circle"
+	case ShapeDoubleCircle:
+		return "doublecircle"
+	default:
+		return "ellipse"
+	}
+}
+
+func (s Shape) mermaidBrackets() (string, string) {
+	switch s {
+	case ShapeBox:
+		return "[", "]"
+	case ShapeCircle:
+		return "((", "))"
+	case ShapeDoubleCircle:
+		return "(((", ")))"
+	default:
+		return "([", "])"
+	}
+}
+
+func dotQuote(text string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(text, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+func mermaidQuote(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
+
+func pathColor(pathIndex int) string {
+	return PathColors[pathIndex%len(PathColors)]
+}
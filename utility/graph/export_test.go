@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func caveGraph() Graph[string] {
+	graph, _ := ParseEdgeList([]string{"start-A", "A-b", "b-end", "A-end"}, "-", false)
+	return graph
+}
+
+func caveOptions(paths ...[]string) ExportOptions[string] {
+	return ExportOptions[string]{
+		Name: "caves",
+		Style: func(vertex string) VertexStyle {
+			if vertex == strings.ToUpper(vertex) {
+				return VertexStyle{Shape: ShapeBox, FillColor: "#cccccc"}
+			}
+			return VertexStyle{}
+		},
+		Paths: paths,
+	}
+}
+
+func TestToDOT(t *testing.T) {
+	graph := caveGraph()
+
+	expected := `graph "caves" {
+  v0 [label="start", shape=ellipse];
+  v1 [label="A", shape=box, style=filled, fillcolor="#cccccc"];
+  v2 [label="b", shape=ellipse];
+  v3 [label="end", shape=ellipse];
+  v0 -- v1 [color="#d62728", penwidth=3];
+  v1 -- v2;
+  v1 -- v3 [color="#d62728", penwidth=3];
+  v2 -- v3;
+}
+`
+
+	if actual := graph.ToDOT(caveOptions([]string{"start", "A", "end"})); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
+
+func TestToDOT_DirectedWithWeights(t *testing.T) {
+	graph := NewDirected[int]()
+	graph.AddWeightedEdge(1, 2, 4)
+
+	expected := `digraph "G" {
+  v0 [label="1", shape=ellipse];
+  v1 [label="2", shape=ellipse];
+  v0 -> v1 [label="4"];
+}
+`
+
+	if actual := graph.ToDOT(ExportOptions[int]{ShowWeights: true}); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
+
+func TestToMermaid(t *testing.T) {
+	graph := caveGraph()
+
+	expected := `graph LR
+  %% caves
+  v0(["start"])
+  v1["A"]
+  v2(["b"])
+  v3(["end"])
+  v0 --- v1
+  v1 --- v2
+  v1 --- v3
+  v2 --- v3
+  style v1 fill:#cccccc
+  linkStyle 0 stroke:#d62728,stroke-width:3px
+  linkStyle 1 stroke:#1f77b4,stroke-width:3px
+  linkStyle 2 stroke:#d62728,stroke-width:3px
+  linkStyle 3 stroke:#1f77b4,stroke-width:3px
+`
+
+	actual := graph.ToMermaid(caveOptions([]string{"start", "A", "end"}, []string{"start", "A", "b", "end"}))
+	if actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
+
+func TestToSVG(t *testing.T) {
+	graph := caveGraph()
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="290" height="60" viewBox="-4 -1 29 6">
+  <line x1="0.5" y1="0.5" x2="10.5" y2="0.5" fill="none" stroke="#d62728" stroke-width="0.3"/>
+  <line x1="10.5" y1="0.5" x2="20.5" y2="0.5" fill="none" stroke="#000000" stroke-width="0.1"/>
+  <line x1="10.5" y1="0.5" x2="20.5" y2="3.5" fill="none" stroke="#d62728" stroke-width="0.3"/>
+  <line x1="20.5" y1="0.5" x2="20.5" y2="3.5" fill="none" stroke="#000000" stroke-width="0.1"/>
+  <rect x="-3" y="0" width="7" height="1" fill="#ffffff" stroke="#000000" stroke-width="0.1"/>
+  <text x="0.5" y="0.5" font-size="0.8" text-anchor="middle" dominant-baseline="central" fill="#000000">start</text>
+  <rect x="7" y="0" width="7" height="1" fill="#cccccc" stroke="#000000" stroke-width="0.1"/>
+  <text x="10.5" y="0.5" font-size="0.8" text-anchor="middle" dominant-baseline="central" fill="#000000">A</text>
+  <rect x="17" y="0" width="7" height="1" fill="#ffffff" stroke="#000000" stroke-width="0.1"/>
+  <text x="20.5" y="0.5" font-size="0.8" text-anchor="middle" dominant-baseline="central" fill="#000000">b</text>
+  <rect x="17" y="3" width="7" height="1" fill="#ffffff" stroke="#000000" stroke-width="0.1"/>
+  <text x="20.5" y="3.5" font-size="0.8" text-anchor="middle" dominant-baseline="central" fill="#000000">end</text>
+</svg>
+`
+
+	svg := graph.ToSVG(caveOptions([]string{"start", "A", "end"}), 10)
+	if actual := svg.String(); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+}
+
+func TestToSVG_UnreachableVerticesGetTheirOwnColumns(t *testing.T) {
+	graph := NewDirected[int]()
+	graph.AddWeightedEdge(1, 2, 4)
+	graph.AddVertex(3)
+
+	svg := graph.ToSVG(ExportOptions[int]{ShowWeights: true}, 10)
+	actual := svg.String()
+
+	for _, fragment := range []string{
+		`<rect x="11" y="0" width="3" height="1"`,
+		`fill="#555555">4</text>`,
+	} {
+		if !strings.Contains(actual, fragment) {
+			t.Logf("Expected %v in\n%v", fragment, actual)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+// Graph is an adjacency list over comparable vertices.
+// Edges without an explicit weight have a weight of 1, so unweighted graphs are simply weighted graphs that never say so.
+// Undirected graphs store each edge in both directions.
+
+type Edge[V comparable] struct {
+	From, To V
+	Weight   int
+}
+
+type Graph[V comparable] struct {
+	directed bool
+	vertices []V
+	adjacent map[V][]Edge[V]
+}
+
+func NewDirected[V comparable]() Graph[V] {
+	return newGraph[V](true)
+}
+
+func NewUndirected[V comparable]() Graph[V] {
+	return newGraph[V](false)
+}
+
+func newGraph[V comparable](directed bool) Graph[V] {
+	return Graph[V]{
+		directed: directed,
+		vertices: []V{},
+		adjacent: make(map[V][]Edge[V]),
+	}
+}
+
+func (g *Graph[V]) AddEdge(from, to V) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+func (g *Graph[V]) AddVertex(vertex V) {
+	if g.HasVertex(vertex) {
+		return
+	}
+
+	g.vertices = append(g.vertices, vertex)
+	g.adjacent[vertex] = []Edge[V]{}
+}
+
+func (g *Graph[V]) AddWeightedEdge(from, to V, weight int) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+
+	g.adjacent[from] = append(g.adjacent[from], Edge[V]{From: from, To: to, Weight: weight})
+	if !g.directed && from != to {
+		g.adjacent[to] = append(g.adjacent[to], Edge[V]{From: to, To: from, Weight: weight})
+	}
+}
+
+// Edges returns every edge in the graph; an undirected edge is returned once, from whichever end was added first.
+func (g *Graph[V]) Edges() []Edge[V] {
+	var edges []Edge[V]
+	seen := make(map[Edge[V]]int)
+
+	for _, vertex := range g.vertices {
+		for _, edge := range g.adjacent[vertex] {
+			if !g.directed && edge.From != edge.To {
+				reversed := Edge[V]{From: edge.To, To: edge.From, Weight: edge.Weight}
+				if seen[reversed] > 0 {
+					seen[reversed]--
+					continue
+				}
+				seen[edge]++
+			}
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+func (g *Graph[V]) EdgesFrom(vertex V) []Edge[V] {
+	return g.adjacent[vertex]
+}
+
+func (g *Graph[V]) ForEachNeighbour(vertex V, visit func(neighbour V, weight int)) {
+	for _, edge := range g.adjacent[vertex] {
+		visit(edge.To, edge.Weight)
+	}
+}
+
+func (g *Graph[V]) HasVertex(vertex V) bool {
+	_, found := g.adjacent[vertex]
+	return found
+}
+
+func (g *Graph[V]) IsDirected() bool {
+	return g.directed
+}
+
+func (g *Graph[V]) Len() int {
+	return len(g.vertices)
+}
+
+func (g *Graph[V]) Neighbours(vertex V) []V {
+	neighbours := make([]V, 0, len(g.adjacent[vertex]))
+	for _, edge := range g.adjacent[vertex] {
+		neighbours = append(neighbours, edge.To)
+	}
+	return neighbours
+}
+
+// Vertices returns every vertex in the order it was first added.
+func (g *Graph[V]) Vertices() []V {
+	return g.vertices
+}
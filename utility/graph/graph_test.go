@@ -0,0 +1,63 @@
+package graph
+
+import "testing"
+
+func TestUndirected_AddEdgeIsSymmetric(t *testing.T) {
+	graph := NewUndirected[string]()
+	graph.AddEdge("a", "b")
+
+	if neighbours := graph.Neighbours("b"); len(neighbours) != 1 || neighbours[0] != "a" {
+		t.Logf("Expected [a], got %v", neighbours)
+		t.Fail()
+	}
+
+	if edges := graph.Edges(); len(edges) != 1 {
+		t.Logf("Expected 1 edge, got %v", edges)
+		t.Fail()
+	}
+}
+
+func TestDirected_AddEdgeIsOneWay(t *testing.T) {
+	graph := NewDirected[string]()
+	graph.AddWeightedEdge("a", "b", 5)
+
+	if neighbours := graph.Neighbours("b"); len(neighbours) != 0 {
+		t.Logf("Expected no neighbours, got %v", neighbours)
+		t.Fail()
+	}
+
+	weight := 0
+	graph.ForEachNeighbour("a", func(_ string, w int) { weight = w })
+	if weight != 5 {
+		t.Logf("Expected 5, got %v", weight)
+		t.Fail()
+	}
+}
+
+func TestParseEdgeList(t *testing.T) {
+	graph, err := ParseEdgeList([]string{"start-A", "A-end 3", ""}, "-", false)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if graph.Len() != 3 {
+		t.Logf("Expected 3 vertices, got %v", graph.Vertices())
+		t.Fail()
+	}
+
+	edges := graph.EdgesFrom("end")
+	if len(edges) != 1 || edges[0].To != "A" || edges[0].Weight != 3 {
+		t.Logf("Expected end-A weighing 3, got %v", edges)
+		t.Fail()
+	}
+}
+
+func TestParseEdgeList_Malformed(t *testing.T) {
+	for _, line := range []string{"start", "start-", "a-b x", "a-b 1 2"} {
+		if _, err := ParseEdgeList([]string{line}, "-", false); err == nil {
+			t.Logf("Expected an error for '%v'", line)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromEdgeList builds a graph from lines of input, one edge per line, with parse turning each line into an edge.
+// Blank lines are skipped.
+func FromEdgeList[V comparable](input []string, directed bool, parse func(line string) (Edge[V], error)) (Graph[V], error) {
+	graph := newGraph[V](directed)
+
+	for index, line := range input {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		edge, err := parse(line)
+		if err != nil {
+			return graph, fmt.Errorf("line %v: %v", index+1, err)
+		}
+
+		graph.AddWeightedEdge(edge.From, edge.To, edge.Weight)
+	}
+
+	return graph, nil
+}
+
+// ParseEdgeList builds a graph of string labels from lines like "start-A", where separator is "-".
+// A line may carry a weight after whitespace, "start-A 7"; otherwise the edge weighs 1.
+func ParseEdgeList(input []string, separator string, directed bool) (Graph[string], error) {
+	return FromEdgeList(input, directed, func(line string) (Edge[string], error) {
+		return ParseEdge(line, separator)
+	})
+}
+
+func ParseEdge(line string, separator string) (Edge[string], error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Edge[string]{}, fmt.Errorf("expected 'from%vto [weight]', got '%v'", separator, line)
+	}
+
+	labels := strings.Split(fields[0], separator)
+	if len(labels) != 2 || len(labels[0]) == 0 || len(labels[1]) == 0 {
+		return Edge[string]{}, fmt.Errorf("expected 'from%vto [weight]', got '%v'", separator, line)
+	}
+
+	weight := 1
+	if len(fields) == 2 {
+		parsed, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return Edge[string]{}, fmt.Errorf("invalid weight '%v': %v", fields[1], err)
+		}
+		weight = parsed
+	}
+
+	return Edge[string]{From: labels[0], To: labels[1], Weight: weight}, nil
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+)
+
+// The searches here work on anything that can list a vertex's neighbours and what it costs to step to each of them,
+// so an implicit graph such as a grid never has to be built as a Graph first.
+// Costs must not be negative.
+
+type NeighbourFunc[V comparable] func(vertex V, visit func(neighbour V, cost int))
+
+type Heuristic[V comparable] func(vertex V) int
+
+type Path[V comparable] struct {
+	Cost     int
+	Vertices []V
+}
+
+// AStar finds the cheapest path from start to the first vertex satisfying isGoal.
+// heuristic must be consistent (monotone): it may never drop by more than the cost of a step, h(u) <= cost(u, v) + h(v),
+// and it must be zero at the goal. A settled vertex is never reopened, so a heuristic that is only admissible can lead
+// to a path that is not the cheapest.
+func AStar[V comparable](start V, isGoal func(vertex V) bool, neighbours NeighbourFunc[V], heuristic Heuristic[V]) (Path[V], bool) {
+	costs := map[V]int{start: 0}
+	previous := make(map[V]V)
+	settled := make(map[V]bool)
+
+	queue := collections.NewPriorityQueue[V]()
+	queue.Push(start, heuristic(start))
+
+	for !queue.IsEmpty() {
+		current, _, _ := queue.Pop()
+		if settled[current] {
+			continue
+		}
+		settled[current] = true
+
+		if isGoal(current) {
+			return Path[V]{Cost: costs[current], Vertices: reconstructPath(previous, start, current)}, true
+		}
+
+		neighbours(current, func(neighbour V, cost int) {
+			if settled[neighbour] {
+				return
+			}
+
+			candidate := costs[current] + cost
+			if known, found := costs[neighbour]; found && known <= candidate {
+				return
+			}
+
+			costs[neighbour] = candidate
+			previous[neighbour] = current
+			queue.Push(neighbour, candidate+heuristic(neighbour))
+		})
+	}
+
+	return Path[V]{}, false
+}
+
+// Dijkstra is AStar without a heuristic.
+func Dijkstra[V comparable](start V, isGoal func(vertex V) bool, neighbours NeighbourFunc[V]) (Path[V], bool) {
+	return AStar(start, isGoal, neighbours, func(V) int { return 0 })
+}
+
+func (g *Graph[V]) AStar(start, end V, heuristic Heuristic[V]) (Path[V], bool) {
+	return AStar(start, func(vertex V) bool { return vertex == end }, g.ForEachNeighbour, heuristic)
+}
+
+func (g *Graph[V]) Dijkstra(start, end V) (Path[V], bool) {
+	return Dijkstra(start, func(vertex V) bool { return vertex == end }, g.ForEachNeighbour)
+}
+
+// GridNeighbours steps between the four orthogonally adjacent, in-bounds, cells of a grid.
+// cost is given the cell being entered and its value.
+func GridNeighbours[T any](grid collections.GridView[T], cost func(to geometry.Coordinate, value T) int) NeighbourFunc[geometry.Coordinate] {
+	return func(vertex geometry.Coordinate, visit func(neighbour geometry.Coordinate, cost int)) {
+		for _, adjacent := range vertex.Adjacent() {
+			if grid.InBounds(adjacent) {
+				visit(adjacent, cost(adjacent, grid.Get(adjacent)))
+			}
+		}
+	}
+}
+
+// ManhattanHeuristic is consistent whenever every step between adjacent cells costs at least 1.
+func ManhattanHeuristic(goal geometry.Coordinate) Heuristic[geometry.Coordinate] {
+	return func(vertex geometry.Coordinate) int {
+		return vertex.Manhattan(goal)
+	}
+}
+
+func reconstructPath[V comparable](previous map[V]V, start, end V) []V {
+	path := []V{end}
+	for current := end; current != start; {
+		current = previous[current]
+		path = append(path, current)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"testing"
+)
+
+var riskExample = []string{
+	"1163751742",
+	"1381373672",
+	"2136511328",
+	"3694931569",
+	"7463417111",
+	"1319128137",
+	"1359912421",
+	"3125421639",
+	"1293138521",
+	"2311944581",
+}
+
+func enterRisk(_ geometry.Coordinate, risk int) int {
+	return risk
+}
+
+func TestGraph_Dijkstra(t *testing.T) {
+	graph := NewDirected[string]()
+	graph.AddWeightedEdge("a", "b", 7)
+	graph.AddWeightedEdge("a", "c", 2)
+	graph.AddWeightedEdge("c", "b", 3)
+	graph.AddWeightedEdge("b", "d", 1)
+
+	path, found := graph.Dijkstra("a", "d")
+	if !found || path.Cost != 6 {
+		t.Logf("Expected a path costing 6, got %v", path)
+		t.FailNow()
+	}
+
+	if len(path.Vertices) != 4 || path.Vertices[1] != "c" {
+		t.Logf("Expected [a c b d], got %v", path.Vertices)
+		t.Fail()
+	}
+}
+
+func TestGraph_DijkstraUnreachable(t *testing.T) {
+	graph := NewDirected[string]()
+	graph.AddEdge("a", "b")
+	graph.AddVertex("c")
+
+	if _, found := graph.Dijkstra("a", "c"); found {
+		t.Log("Expected no path")
+		t.Fail()
+	}
+}
+
+func TestGridDijkstraAndAStarAgree(t *testing.T) {
+	grid := collections.ParseDigitGrid(riskExample)
+	tiled := collections.NewTiledGrid[int](&grid, 5, 5, func(value int, tile geometry.Coordinate) int {
+		return (value+tile.X+tile.Y-1)%9 + 1
+	})
+
+	for _, testCase := range []struct {
+		view     collections.GridView[int]
+		expected int
+	}{{&grid, 40}, {&tiled, 315}} {
+		start := geometry.NewCoordinate(0, 0)
+		goal := geometry.NewCoordinate(testCase.view.Width()-1, testCase.view.Height()-1)
+		isGoal := func(c geometry.Coordinate) bool { return c == goal }
+		neighbours := GridNeighbours(testCase.view, enterRisk)
+
+		dijkstra, _ := Dijkstra(start, isGoal, neighbours)
+		aStar, _ := AStar(start, isGoal, neighbours, ManhattanHeuristic(goal))
+
+		if dijkstra.Cost != testCase.expected || aStar.Cost != testCase.expected {
+			t.Logf("Expected %v, got %v from Dijkstra and %v from A*", testCase.expected, dijkstra.Cost, aStar.Cost)
+			t.Fail()
+		}
+
+		pathCost := 0
+		for _, step := range aStar.Vertices[1:] {
+			pathCost += testCase.view.Get(step)
+		}
+		if pathCost != aStar.Cost || aStar.Vertices[0] != start {
+			t.Logf("Expected the reconstructed path to cost %v, got %v", aStar.Cost, pathCost)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package graph
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"errors"
+)
+
+// BreadthFirst visits every vertex reachable from start in order of distance, counted in edges.
+// Returning false from visit stops the search.
+func (g *Graph[V]) BreadthFirst(start V, visit func(vertex V, depth int) bool) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	depths := map[V]int{start: 0}
+	queue := []V{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if !visit(current, depths[current]) {
+			return
+		}
+
+		for _, edge := range g.adjacent[current] {
+			if _, seen := depths[edge.To]; !seen {
+				depths[edge.To] = depths[current] + 1
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+}
+
+// DepthFirst visits every vertex reachable from start, following edges in the order they were added.
+// Returning false from visit stops the search.
+func (g *Graph[V]) DepthFirst(start V, visit func(vertex V) bool) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	visited := make(map[V]bool)
+	stack := collections.NewStack()
+	stack.Push(start)
+
+	for !stack.IsEmpty() {
+		item, _ := stack.Pop()
+		current := item.(V)
+
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		if !visit(current) {
+			return
+		}
+
+		edges := g.adjacent[current]
+		for index := len(edges) - 1; index >= 0; index-- {
+			if !visited[edges[index].To] {
+				stack.Push(edges[index].To)
+			}
+		}
+	}
+}
+
+// ConnectedComponents groups the vertices that can reach one another ignoring edge direction,
+// so for a directed graph these are its weakly connected components.
+func (g *Graph[V]) ConnectedComponents() [][]V {
+	components := collections.NewDisjointSet[V]()
+
+	for _, vertex := range g.vertices {
+		components.Add(vertex)
+		for _, edge := range g.adjacent[vertex] {
+			components.Union(vertex, edge.To)
+		}
+	}
+
+	return components.Components()
+}
+
+// HasCycle reports whether any cycle exists.
+// For an undirected graph, walking an edge and straight back along it is not a cycle.
+func (g *Graph[V]) HasCycle() bool {
+	if g.directed {
+		_, err := g.TopologicalSort()
+		return err != nil
+	}
+
+	components := collections.NewDisjointSet[V]()
+	for _, edge := range g.Edges() {
+		if !components.Union(edge.From, edge.To) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TopologicalSort orders the vertices of a directed acyclic graph so every edge points forwards.
+// Ties are broken by the order vertices were added.
+func (g *Graph[V]) TopologicalSort() ([]V, error) {
+	if !g.directed {
+		return nil, errors.New("topological sort requires a directed graph")
+	}
+
+	inDegrees := make(map[V]int)
+	for _, vertex := range g.vertices {
+		for _, edge := range g.adjacent[vertex] {
+			inDegrees[edge.To]++
+		}
+	}
+
+	var queue []V
+	for _, vertex := range g.vertices {
+		if inDegrees[vertex] == 0 {
+			queue = append(queue, vertex)
+		}
+	}
+
+	var sorted []V
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, current)
+
+		for _, edge := range g.adjacent[current] {
+			inDegrees[edge.To]--
+			if inDegrees[edge.To] == 0 {
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+
+	if len(sorted) != len(g.vertices) {
+		return nil, errors.New("graph contains a cycle")
+	}
+
+	return sorted, nil
+}
+
+// EnumeratePaths walks every path from start that canEnter allows, calling found with each one that reaches end.
+// visits counts how many times each vertex appears on the path so far, start included.
+// A path stops as soon as it reaches end. The path handed to found is only valid for the duration of the call.
+func (g *Graph[V]) EnumeratePaths(start, end V, canEnter func(next V, visits map[V]int) bool, found func(path []V)) int {
+	visits := map[V]int{start: 1}
+	path := []V{start}
+
+	var walk func(current V) int
+	walk = func(current V) int {
+		pathCount := 0
+
+		for _, edge := range g.adjacent[current] {
+			next := edge.To
+			if !canEnter(next, visits) {
+				continue
+			}
+
+			path = append(path, next)
+
+			if next == end {
+				pathCount++
+				found(path)
+			} else {
+				visits[next]++
+				pathCount += walk(next)
+				visits[next]--
+			}
+
+			path = path[:len(path)-1]
+		}
+
+		return pathCount
+	}
+
+	return walk(start)
+}
@@ -0,0 +1,136 @@
+package graph
+
+import "testing"
+
+func TestBreadthFirst_Depths(t *testing.T) {
+	graph := NewUndirected[int]()
+	graph.AddEdge(1, 2)
+	graph.AddEdge(2, 3)
+	graph.AddEdge(1, 4)
+	graph.AddEdge(4, 3)
+
+	depths := make(map[int]int)
+	graph.BreadthFirst(1, func(vertex int, depth int) bool {
+		depths[vertex] = depth
+		return true
+	})
+
+	if depths[3] != 2 || depths[4] != 1 || len(depths) != 4 {
+		t.Logf("Unexpected depths %v", depths)
+		t.Fail()
+	}
+}
+
+func TestDepthFirst_Order(t *testing.T) {
+	graph := NewDirected[string]()
+	graph.AddEdge("a", "b")
+	graph.AddEdge("b", "c")
+	graph.AddEdge("a", "d")
+
+	var order []string
+	graph.DepthFirst("a", func(vertex string) bool {
+		order = append(order, vertex)
+		return vertex != "c"
+	})
+
+	if len(order) != 3 || order[1] != "b" || order[2] != "c" {
+		t.Logf("Expected [a b c], got %v", order)
+		t.Fail()
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	graph := NewUndirected[int]()
+	graph.AddEdge(1, 2)
+	graph.AddEdge(3, 4)
+	graph.AddVertex(5)
+
+	if components := graph.ConnectedComponents(); len(components) != 3 {
+		t.Logf("Expected 3 components, got %v", components)
+		t.Fail()
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	graph := NewDirected[string]()
+	graph.AddEdge("shirt", "tie")
+	graph.AddEdge("tie", "jacket")
+	graph.AddEdge("trousers", "shoes")
+	graph.AddEdge("trousers", "jacket")
+
+	sorted, err := graph.TopologicalSort()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	positions := make(map[string]int)
+	for index, vertex := range sorted {
+		positions[vertex] = index
+	}
+
+	for _, edge := range graph.Edges() {
+		if positions[edge.From] > positions[edge.To] {
+			t.Logf("%v should come before %v in %v", edge.From, edge.To, sorted)
+			t.Fail()
+		}
+	}
+}
+
+func TestHasCycle(t *testing.T) {
+	directed := NewDirected[int]()
+	directed.AddEdge(1, 2)
+	directed.AddEdge(2, 3)
+	if directed.HasCycle() {
+		t.Log("Expected a chain to be acyclic")
+		t.Fail()
+	}
+
+	directed.AddEdge(3, 1)
+	if !directed.HasCycle() {
+		t.Log("Expected a cycle")
+		t.Fail()
+	}
+
+	if _, err := directed.TopologicalSort(); err == nil {
+		t.Log("Expected an error sorting a cyclic graph")
+		t.Fail()
+	}
+
+	undirected := NewUndirected[int]()
+	undirected.AddEdge(1, 2)
+	undirected.AddEdge(2, 3)
+	if undirected.HasCycle() {
+		t.Log("Expected an undirected tree to be acyclic")
+		t.Fail()
+	}
+
+	undirected.AddEdge(3, 1)
+	if !undirected.HasCycle() {
+		t.Log("Expected a cycle")
+		t.Fail()
+	}
+}
+
+func TestEnumeratePaths(t *testing.T) {
+	graph, _ := ParseEdgeList([]string{"start-A", "start-b", "A-b", "A-end", "b-end"}, "-", false)
+
+	onceOnly := func(next string, visits map[string]int) bool { return visits[next] == 0 }
+
+	var paths [][]string
+	count := graph.EnumeratePaths("start", "end", onceOnly, func(path []string) {
+		paths = append(paths, append([]string{}, path...))
+	})
+
+	if count != 4 || len(paths) != 4 {
+		t.Logf("Expected 4 paths, got %v", paths)
+		t.Fail()
+	}
+
+	for _, path := range paths {
+		if path[0] != "start" || path[len(path)-1] != "end" {
+			t.Logf("Expected path from start to end, got %v", path)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package intervals
+
+import "strings"
+
+// Box is an axis-aligned box of integer points, one closed Interval per dimension.
+// Boxes of different dimensions never overlap.
+
+type Box struct {
+	axes []Interval
+}
+
+func NewBox(axes ...Interval) Box {
+	return Box{axes: append([]Interval{}, axes...)}
+}
+
+func NewBox2(x, y Interval) Box {
+	return NewBox(x, y)
+}
+
+func NewBox3(x, y, z Interval) Box {
+	return NewBox(x, y, z)
+}
+
+func (b Box) Axis(dimension int) Interval {
+	return b.axes[dimension]
+}
+
+func (b Box) Contains(point ...int) bool {
+	if len(point) != len(b.axes) {
+		return false
+	}
+	for dimension, value := range point {
+		if !b.axes[dimension].Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Box) Dimensions() int {
+	return len(b.axes)
+}
+
+func (b Box) Equal(other Box) bool {
+	if len(b.axes) != len(other.axes) {
+		return false
+	}
+	for dimension := range b.axes {
+		if b.axes[dimension] != other.axes[dimension] {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the box shared by both, which is empty if they do not overlap.
+func (b Box) Intersect(other Box) Box {
+	if len(b.axes) != len(other.axes) {
+		return Box{}
+	}
+
+	axes := make([]Interval, len(b.axes))
+	for dimension := range b.axes {
+		axes[dimension] = b.axes[dimension].Intersect(other.axes[dimension])
+	}
+	return Box{axes: axes}
+}
+
+func (b Box) IsEmpty() bool {
+	if len(b.axes) == 0 {
+		return true
+	}
+	for _, axis := range b.axes {
+		if axis.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+func (b Box) Overlaps(other Box) bool {
+	return !b.Intersect(other).IsEmpty()
+}
+
+func (b Box) String() string {
+	var axes []string
+	for _, axis := range b.axes {
+		axes = append(axes, axis.String())
+	}
+	return strings.Join(axes, "x")
+}
+
+// Subtract splits what is left of the box once other is taken out of it into disjoint boxes, at most two per dimension.
+// Each dimension in turn slices off the parts below and above the overlap, then narrows to the overlap before the next.
+func (b Box) Subtract(other Box) []Box {
+	if b.IsEmpty() {
+		return nil
+	}
+
+	overlap := b.Intersect(other)
+	if overlap.IsEmpty() {
+		return []Box{b}
+	}
+
+	var remaining []Box
+	core := NewBox(b.axes...)
+
+	for dimension := range core.axes {
+		for _, piece := range core.axes[dimension].Subtract(overlap.axes[dimension]) {
+			slice := NewBox(core.axes...)
+			slice.axes[dimension] = piece
+			remaining = append(remaining, slice)
+		}
+		core.axes[dimension] = overlap.axes[dimension]
+	}
+
+	return remaining
+}
+
+// Volume is the number of integer points in the box: its area in 2D, volume in 3D.
+func (b Box) Volume() int {
+	if b.IsEmpty() {
+		return 0
+	}
+
+	volume := 1
+	for _, axis := range b.axes {
+		volume *= axis.Len()
+	}
+	return volume
+}
+
+// BoxSet is a union of boxes held as disjoint pieces, so its volume is simply the sum of theirs.
+// Adding and removing boxes in turn is exactly what reactor-reboot style puzzles ask for.
+
+type BoxSet struct {
+	boxes []Box
+}
+
+func NewBoxSet() BoxSet {
+	return BoxSet{}
+}
+
+func (s *BoxSet) Add(box Box) {
+	s.Remove(box)
+	if !box.IsEmpty() {
+		s.boxes = append(s.boxes, box)
+	}
+}
+
+// Boxes returns the disjoint pieces currently making up the set.
+func (s *BoxSet) Boxes() []Box {
+	return append([]Box{}, s.boxes...)
+}
+
+func (s *BoxSet) Contains(point ...int) bool {
+	for _, box := range s.boxes {
+		if box.Contains(point...) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *BoxSet) Remove(removed Box) {
+	var remaining []Box
+	for _, box := range s.boxes {
+		remaining = append(remaining, box.Subtract(removed)...)
+	}
+	s.boxes = remaining
+}
+
+func (s *BoxSet) Volume() int {
+	volume := 0
+	for _, box := range s.boxes {
+		volume += box.Volume()
+	}
+	return volume
+}
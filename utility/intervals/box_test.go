@@ -0,0 +1,64 @@
+package intervals
+
+import "testing"
+
+func TestBox_Volume(t *testing.T) {
+	if area := NewBox2(NewInterval(0, 2), NewInterval(0, 3)).Volume(); area != 12 {
+		t.Logf("Expected 12, got %v", area)
+		t.Fail()
+	}
+
+	if volume := NewBox3(NewInterval(10, 12), NewInterval(10, 12), NewInterval(10, 12)).Volume(); volume != 27 {
+		t.Logf("Expected 27, got %v", volume)
+		t.Fail()
+	}
+}
+
+func TestBox_SubtractLeavesDisjointRemainder(t *testing.T) {
+	box := NewBox3(NewInterval(0, 9), NewInterval(0, 9), NewInterval(0, 9))
+	hole := NewBox3(NewInterval(3, 5), NewInterval(3, 5), NewInterval(3, 5))
+
+	pieces := box.Subtract(hole)
+
+	volume := 0
+	for index, piece := range pieces {
+		volume += piece.Volume()
+		if piece.Overlaps(hole) {
+			t.Logf("Piece %v overlaps the hole", piece)
+			t.Fail()
+		}
+		for _, other := range pieces[index+1:] {
+			if piece.Overlaps(other) {
+				t.Logf("Pieces %v and %v overlap", piece, other)
+				t.Fail()
+			}
+		}
+	}
+
+	if volume != 1000-27 || len(pieces) != 6 {
+		t.Logf("Expected 6 pieces totalling 973, got %v totalling %v", len(pieces), volume)
+		t.Fail()
+	}
+}
+
+func TestBoxSet_ReactorReboot(t *testing.T) {
+	cube := func(min, max int) Box {
+		return NewBox3(NewInterval(min, max), NewInterval(min, max), NewInterval(min, max))
+	}
+
+	reactor := NewBoxSet()
+	reactor.Add(cube(10, 12))
+	reactor.Add(cube(11, 13))
+	reactor.Remove(cube(9, 11))
+	reactor.Add(cube(10, 10))
+
+	if volume := reactor.Volume(); volume != 39 {
+		t.Logf("Expected 39, got %v", volume)
+		t.Fail()
+	}
+
+	if !reactor.Contains(10, 10, 10) || reactor.Contains(11, 11, 11) {
+		t.Log("Unexpected cubes lit")
+		t.Fail()
+	}
+}
@@ -0,0 +1,127 @@
+package intervals
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interval is the closed range of integers from Min to Max, both included.
+// An Interval with Min greater than Max is empty; Empty is the canonical one.
+
+type Interval struct {
+	Min, Max int
+}
+
+var Empty = Interval{Min: 0, Max: -1}
+
+func NewInterval(min, max int) Interval {
+	return Interval{Min: min, Max: max}
+}
+
+// Span returns the smallest Interval covering both a and b, in either order.
+func Span(a, b int) Interval {
+	if a > b {
+		a, b = b, a
+	}
+	return Interval{Min: a, Max: b}
+}
+
+// Merge sorts intervals and combines any that overlap or sit directly next to one another, dropping empty ones.
+func Merge(intervals []Interval) []Interval {
+	var sorted []Interval
+	for _, interval := range intervals {
+		if !interval.IsEmpty() {
+			sorted = append(sorted, interval)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Min < sorted[j].Min })
+
+	var merged []Interval
+	for _, interval := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && interval.Min <= merged[last].Max+1 {
+			if interval.Max > merged[last].Max {
+				merged[last].Max = interval.Max
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+
+	return merged
+}
+
+func (i Interval) Contains(value int) bool {
+	return value >= i.Min && value <= i.Max
+}
+
+func (i Interval) ContainsInterval(other Interval) bool {
+	return other.IsEmpty() || (!i.IsEmpty() && other.Min >= i.Min && other.Max <= i.Max)
+}
+
+func (i Interval) Intersect(other Interval) Interval {
+	intersection := Interval{Min: max(i.Min, other.Min), Max: min(i.Max, other.Max)}
+	if intersection.IsEmpty() {
+		return Empty
+	}
+	return intersection
+}
+
+func (i Interval) IsEmpty() bool {
+	return i.Min > i.Max
+}
+
+// Len is the number of integers in the interval.
+func (i Interval) Len() int {
+	if i.IsEmpty() {
+		return 0
+	}
+	return i.Max - i.Min + 1
+}
+
+func (i Interval) Overlaps(other Interval) bool {
+	return !i.Intersect(other).IsEmpty()
+}
+
+func (i Interval) String() string {
+	if i.IsEmpty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%v..%v]", i.Min, i.Max)
+}
+
+// Subtract returns what is left of the interval once other is taken out of it: nothing, one piece, or two.
+func (i Interval) Subtract(other Interval) []Interval {
+	if i.IsEmpty() {
+		return nil
+	}
+
+	overlap := i.Intersect(other)
+	if overlap.IsEmpty() {
+		return []Interval{i}
+	}
+
+	var remaining []Interval
+	if i.Min < overlap.Min {
+		remaining = append(remaining, Interval{Min: i.Min, Max: overlap.Min - 1})
+	}
+	if overlap.Max < i.Max {
+		remaining = append(remaining, Interval{Min: overlap.Max + 1, Max: i.Max})
+	}
+	return remaining
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package intervals
+
+import "testing"
+
+func TestInterval_Len(t *testing.T) {
+	if length := NewInterval(3, 7).Len(); length != 5 {
+		t.Logf("Expected 5, got %v", length)
+		t.Fail()
+	}
+
+	if length := NewInterval(7, 3).Len(); length != 0 {
+		t.Logf("Expected an inverted interval to be empty, got %v", length)
+		t.Fail()
+	}
+}
+
+func TestInterval_Intersect(t *testing.T) {
+	if overlap := NewInterval(0, 5).Intersect(NewInterval(3, 9)); overlap != NewInterval(3, 5) {
+		t.Logf("Expected [3..5], got %v", overlap)
+		t.Fail()
+	}
+
+	if overlap := NewInterval(0, 2).Intersect(NewInterval(3, 9)); !overlap.IsEmpty() {
+		t.Logf("Expected no overlap, got %v", overlap)
+		t.Fail()
+	}
+}
+
+func TestInterval_Subtract(t *testing.T) {
+	pieces := NewInterval(0, 9).Subtract(NewInterval(3, 5))
+	if len(pieces) != 2 || pieces[0] != NewInterval(0, 2) || pieces[1] != NewInterval(6, 9) {
+		t.Logf("Expected [0..2] and [6..9], got %v", pieces)
+		t.Fail()
+	}
+
+	if pieces := NewInterval(3, 5).Subtract(NewInterval(0, 9)); len(pieces) != 0 {
+		t.Logf("Expected nothing left, got %v", pieces)
+		t.Fail()
+	}
+
+	if pieces := NewInterval(0, 5).Subtract(NewInterval(5, 9)); len(pieces) != 1 || pieces[0] != NewInterval(0, 4) {
+		t.Logf("Expected [0..4], got %v", pieces)
+		t.Fail()
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge([]Interval{NewInterval(10, 12), NewInterval(0, 3), NewInterval(4, 6), NewInterval(2, 5), Empty})
+	if len(merged) != 2 || merged[0] != NewInterval(0, 6) || merged[1] != NewInterval(10, 12) {
+		t.Logf("Expected [0..6] and [10..12], got %v", merged)
+		t.Fail()
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	set := NewRangeSet(NewInterval(0, 9))
+	set.Remove(NewInterval(3, 4))
+	set.Add(NewInterval(20, 21))
+
+	if set.Len() != 10 {
+		t.Logf("Expected 10, got %v from %v", set.Len(), set.Intervals())
+		t.Fail()
+	}
+
+	if set.Contains(3) || !set.Contains(5) || !set.Contains(21) {
+		t.Logf("Unexpected membership in %v", set.Intervals())
+		t.Fail()
+	}
+
+	other := NewRangeSet(NewInterval(8, 20))
+	if intersection := set.Intersect(other); intersection.Len() != 3 {
+		t.Logf("Expected 3, got %v", intersection.Intervals())
+		t.Fail()
+	}
+
+	if union := set.Union(other); union.Len() != 20 || len(union.Intervals()) != 2 {
+		t.Logf("Expected [0..2] and [5..21], got %v", union.Intervals())
+		t.Fail()
+	}
+}
@@ -0,0 +1,69 @@
+package intervals
+
+// RangeSet is a set of integers held as sorted, disjoint, non-adjacent intervals.
+
+type RangeSet struct {
+	intervals []Interval
+}
+
+func NewRangeSet(intervals ...Interval) RangeSet {
+	return RangeSet{intervals: Merge(intervals)}
+}
+
+func (r *RangeSet) Add(interval Interval) {
+	r.intervals = Merge(append(r.intervals, interval))
+}
+
+func (r *RangeSet) Contains(value int) bool {
+	for _, interval := range r.intervals {
+		if interval.Contains(value) {
+			return true
+		}
+		if interval.Min > value {
+			return false
+		}
+	}
+	return false
+}
+
+func (r *RangeSet) Intersect(other RangeSet) RangeSet {
+	var intersections []Interval
+	for _, interval := range r.intervals {
+		for _, otherInterval := range other.intervals {
+			if overlap := interval.Intersect(otherInterval); !overlap.IsEmpty() {
+				intersections = append(intersections, overlap)
+			}
+		}
+	}
+	return NewRangeSet(intersections...)
+}
+
+// Intervals returns the disjoint intervals making up the set, in ascending order.
+func (r *RangeSet) Intervals() []Interval {
+	return append([]Interval{}, r.intervals...)
+}
+
+func (r *RangeSet) IsEmpty() bool {
+	return len(r.intervals) == 0
+}
+
+// Len is the total number of integers in the set.
+func (r *RangeSet) Len() int {
+	total := 0
+	for _, interval := range r.intervals {
+		total += interval.Len()
+	}
+	return total
+}
+
+func (r *RangeSet) Remove(removed Interval) {
+	var remaining []Interval
+	for _, interval := range r.intervals {
+		remaining = append(remaining, interval.Subtract(removed)...)
+	}
+	r.intervals = remaining
+}
+
+func (r *RangeSet) Union(other RangeSet) RangeSet {
+	return NewRangeSet(append(r.Intervals(), other.intervals...)...)
+}
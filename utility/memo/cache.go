@@ -0,0 +1,113 @@
+package memo
+
+import "container/list"
+
+// Cache remembers the values computed for keys, optionally holding on to only the most recently used capacity of them.
+// It keeps count of hits and misses so a solver can report how well its memoization is paying off.
+// Like the rest of the collections here, it is not safe for concurrent use.
+
+type Cache[K comparable, V any] struct {
+	capacity int
+	entries  map[K]*list.Element
+	recency  *list.List
+	stats    Stats
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+type Stats struct {
+	Hits, Misses, Evictions int
+}
+
+func (s Stats) HitRate() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+// NewCache returns a Cache that never forgets.
+func NewCache[K comparable, V any]() Cache[K, V] {
+	return NewBoundedCache[K, V](0)
+}
+
+// NewBoundedCache returns a Cache that evicts the least recently used entry once it holds capacity of them.
+// A capacity of zero or less means unbounded.
+func NewBoundedCache[K comparable, V any](capacity int) Cache[K, V] {
+	return Cache[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element),
+		recency:  list.New(),
+	}
+}
+
+func (c *Cache[K, V]) Clear() {
+	c.entries = make(map[K]*list.Element)
+	c.recency.Init()
+}
+
+// Get looks up key, counting a hit or a miss.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	element, found := c.entries[key]
+	if !found {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	c.recency.MoveToFront(element)
+	return element.Value.(cacheEntry[K, V]).value, true
+}
+
+// GetOrCompute returns the remembered value for key, computing and remembering it first if need be.
+func (c *Cache[K, V]) GetOrCompute(key K, compute func(key K) V) V {
+	if value, found := c.Get(key); found {
+		return value
+	}
+
+	value := compute(key)
+	c.Put(key, value)
+	return value
+}
+
+func (c *Cache[K, V]) Len() int {
+	return len(c.entries)
+}
+
+func (c *Cache[K, V]) Put(key K, value V) {
+	if element, found := c.entries[key]; found {
+		element.Value = cacheEntry[K, V]{key: key, value: value}
+		c.recency.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.recency.PushFront(cacheEntry[K, V]{key: key, value: value})
+
+	if c.capacity > 0 && c.recency.Len() > c.capacity {
+		oldest := c.recency.Back()
+		c.recency.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry[K, V]).key)
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Recursive memoizes a recursive function through cache.
+// fn is handed the memoized function to make its recursive calls through, so every level of the recursion is remembered.
+func Recursive[K comparable, V any](cache *Cache[K, V], fn func(recurse func(key K) V, key K) V) func(key K) V {
+	var memoized func(key K) V
+	memoized = func(key K) V {
+		return cache.GetOrCompute(key, func(key K) V {
+			return fn(memoized, key)
+		})
+	}
+	return memoized
+}
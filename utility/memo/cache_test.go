@@ -0,0 +1,92 @@
+package memo
+
+import "testing"
+
+func TestCache_GetCountsHitsAndMisses(t *testing.T) {
+	cache := NewCache[string, int]()
+
+	if _, found := cache.Get("a"); found {
+		t.Log("Expected a miss on an empty cache")
+		t.Fail()
+	}
+
+	cache.Put("a", 1)
+	if value, found := cache.Get("a"); !found || value != 1 {
+		t.Logf("Expected 1, got %v", value)
+		t.Fail()
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.HitRate() != 0.5 {
+		t.Logf("Expected 1 hit and 1 miss, got %+v", stats)
+		t.Fail()
+	}
+}
+
+func TestBoundedCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewBoundedCache[int, int](2)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Get(1)
+	cache.Put(3, 3)
+
+	if _, found := cache.Get(2); found {
+		t.Log("Expected 2 to have been evicted")
+		t.Fail()
+	}
+
+	if _, found := cache.Get(1); !found {
+		t.Log("Expected 1 to have survived as it was used more recently")
+		t.Fail()
+	}
+
+	if cache.Len() != 2 || cache.Stats().Evictions != 1 {
+		t.Logf("Expected 2 entries and 1 eviction, got %v and %+v", cache.Len(), cache.Stats())
+		t.Fail()
+	}
+}
+
+func TestRecursive(t *testing.T) {
+	cache := NewCache[int, int]()
+	calls := 0
+
+	fibonacci := Recursive(&cache, func(recurse func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return recurse(n-1) + recurse(n-2)
+	})
+
+	if value := fibonacci(80); value != 23416728348467685 {
+		t.Logf("Expected 23416728348467685, got %v", value)
+		t.Fail()
+	}
+
+	if calls != 81 {
+		t.Logf("Expected each value to be computed once, got %v calls", calls)
+		t.Fail()
+	}
+}
+
+func TestRecursive_Bounded(t *testing.T) {
+	cache := NewBoundedCache[int, int](3)
+
+	fibonacci := Recursive(&cache, func(recurse func(int) int, n int) int {
+		if n < 2 {
+			return n
+		}
+		return recurse(n-1) + recurse(n-2)
+	})
+
+	if value := fibonacci(30); value != 832040 {
+		t.Logf("Expected 832040, got %v", value)
+		t.Fail()
+	}
+
+	if cache.Len() > 3 {
+		t.Logf("Expected at most 3 entries, got %v", cache.Len())
+		t.Fail()
+	}
+}
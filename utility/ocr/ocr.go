@@ -0,0 +1,150 @@
+package ocr
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Font reads block letters drawn a fixed width and height apart, with lit cells as '#' and anything else dark.
+// Letters are read left to right from wherever the lit cells start; as some letters have a dark first column,
+// the first letter may begin up to a letter's width before the first lit cell.
+
+type Font struct {
+	width, height, spacing int
+	glyphs                 map[string]rune
+	letters                map[rune][]string
+}
+
+var Fonts = []Font{Font4x6, Font6x10}
+
+func NewFont(width, height, spacing int, letters map[rune][]string) Font {
+	glyphs := make(map[string]rune)
+	for letter, rows := range letters {
+		glyphs[strings.Join(rows, "\n")] = letter
+	}
+
+	return Font{
+		width:   width,
+		height:  height,
+		spacing: spacing,
+		glyphs:  glyphs,
+		letters: letters,
+	}
+}
+
+// Recognize reads text with whichever of the Fonts is as tall as the lit cells are.
+func Recognize(lines []string) (string, error) {
+	return RecognizeCoordinates(litCoordinates(lines))
+}
+
+// RecognizeCoordinates reads the lit coordinates with whichever of the Fonts is as tall as they are.
+func RecognizeCoordinates(coordinates []geometry.Coordinate) (string, error) {
+	height := geometry.BoundingRect(coordinates).Height()
+	for _, font := range Fonts {
+		if font.height == height {
+			return font.RecognizeCoordinates(coordinates)
+		}
+	}
+	return "", fmt.Errorf("no font is %v cells tall", height)
+}
+
+func (f Font) Height() int {
+	return f.height
+}
+
+func (f Font) Recognize(lines []string) (string, error) {
+	return f.RecognizeCoordinates(litCoordinates(lines))
+}
+
+func (f Font) RecognizeCoordinates(coordinates []geometry.Coordinate) (string, error) {
+	bounds := geometry.BoundingRect(coordinates)
+	if bounds.IsEmpty() {
+		return "", errors.New("there are no lit cells to read")
+	}
+	if bounds.Height() != f.height {
+		return "", fmt.Errorf("expected letters %v cells tall, got %v", f.height, bounds.Height())
+	}
+
+	lit := make(map[geometry.Coordinate]bool)
+	for _, coordinate := range coordinates {
+		lit[coordinate] = true
+	}
+
+	var firstErr error
+	for offset := 0; offset < f.width; offset++ {
+		text, err := f.read(lit, bounds.Min.X-offset, bounds)
+		if err == nil {
+			return text, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return "", firstErr
+}
+
+// Render draws text in the font, the inverse of Recognize.
+func (f Font) Render(text string) ([]string, error) {
+	lines := make([]string, f.height)
+	for index, letter := range text {
+		rows, found := f.letters[letter]
+		if !found {
+			return nil, fmt.Errorf("the font has no '%c'", letter)
+		}
+
+		for row := range lines {
+			if index > 0 {
+				lines[row] += strings.Repeat(".", f.spacing)
+			}
+			lines[row] += rows[row]
+		}
+	}
+	return lines, nil
+}
+
+func (f Font) Width() int {
+	return f.width
+}
+
+func (f Font) read(lit map[geometry.Coordinate]bool, left int, bounds geometry.Rect) (string, error) {
+	var text strings.Builder
+
+	for x := left; x <= bounds.Max.X; x += f.width + f.spacing {
+		glyph := geometry.NewRect(geometry.NewCoordinate(x, bounds.Min.Y), geometry.NewCoordinate(x+f.width-1, bounds.Max.Y))
+
+		var rows strings.Builder
+		glyph.ForEach(func(coordinate geometry.Coordinate) {
+			if coordinate.X == glyph.Min.X && coordinate.Y > glyph.Min.Y {
+				rows.WriteByte('\n')
+			}
+			if lit[coordinate] {
+				rows.WriteByte('#')
+			} else {
+				rows.WriteByte('.')
+			}
+		})
+
+		letter, found := f.glyphs[rows.String()]
+		if !found {
+			return "", fmt.Errorf("unrecognised letter at column %v:\n%v", x, rows.String())
+		}
+		text.WriteRune(letter)
+	}
+
+	return text.String(), nil
+}
+
+func litCoordinates(lines []string) []geometry.Coordinate {
+	var coordinates []geometry.Coordinate
+	for y, line := range lines {
+		for x, char := range []rune(line) {
+			if char == '#' || char == '█' {
+				coordinates = append(coordinates, geometry.NewCoordinate(x, y))
+			}
+		}
+	}
+	return coordinates
+}
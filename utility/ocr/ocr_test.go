@@ -0,0 +1,68 @@
+package ocr
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"strings"
+	"testing"
+)
+
+func TestRecognize(t *testing.T) {
+	lines := []string{
+		"#..#...##.###..#..#.####.#..#.###...##.",
+		"#.#.....#.#..#.#.#..#....#..#.#..#.#..#",
+		"##......#.###..##...###..#..#.###..#...",
+		"#.#.....#.#..#.#.#..#....#..#.#..#.#.##",
+		"#.#..#..#.#..#.#.#..#....#..#.#..#.#..#",
+		"#..#..##..###..#..#.####..##..###...###",
+	}
+
+	text, err := Recognize(lines)
+	if err != nil || text != "KJBKEUBG" {
+		t.Logf("Expected KJBKEUBG, got %v (%v)", text, err)
+		t.Fail()
+	}
+}
+
+func TestFont_RenderRoundTrips(t *testing.T) {
+	for _, font := range Fonts {
+		var alphabet strings.Builder
+		for letter := range font.letters {
+			alphabet.WriteRune(letter)
+		}
+
+		lines, err := font.Render(alphabet.String())
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		text, err := font.Recognize(lines)
+		if err != nil || text != alphabet.String() {
+			t.Logf("Expected %v, got %v (%v)", alphabet.String(), text, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestRecognizeCoordinates_LeadingDarkColumn(t *testing.T) {
+	lines, _ := Font4x6.Render("IJL")
+
+	// Shift the letters well away from the origin, as dots left behind by folding can be.
+	var coordinates []geometry.Coordinate
+	for _, coordinate := range litCoordinates(lines) {
+		coordinates = append(coordinates, coordinate.Add(geometry.NewCoordinate(40, -7)))
+	}
+
+	text, err := RecognizeCoordinates(coordinates)
+	if err != nil || text != "IJL" {
+		t.Logf("Expected IJL, got %v (%v)", text, err)
+		t.Fail()
+	}
+}
+
+func TestRecognize_Unknown(t *testing.T) {
+	if _, err := Recognize([]string{"#####", "#...#", "#...#", "#...#", "#...#", "#####"}); err == nil {
+		t.Log("Expected an error for a shape that is not a letter")
+		t.Fail()
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Animation collects frames of a simulation and writes them out as a looping GIF.
+// Frames are reduced to the palette exactly, without dithering, so the palette should hold every colour drawn.
+
+type Animation struct {
+	palette color.Palette
+	delay   int
+	frames  []*image.Paletted
+}
+
+// NewAnimation shows each frame for delay hundredths of a second.
+func NewAnimation(palette color.Palette, delay int) Animation {
+	return Animation{
+		palette: palette,
+		delay:   delay,
+	}
+}
+
+func (a *Animation) AddFrame(frame image.Image) error {
+	if len(a.frames) > 0 && frame.Bounds() != a.frames[0].Bounds() {
+		return fmt.Errorf("frame is %v but the animation is %v", frame.Bounds(), a.frames[0].Bounds())
+	}
+
+	paletted := image.NewPaletted(frame.Bounds(), a.palette)
+	draw.Draw(paletted, paletted.Rect, frame, frame.Bounds().Min, draw.Src)
+	a.frames = append(a.frames, paletted)
+
+	return nil
+}
+
+func (a *Animation) Encode(writer io.Writer) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation has no frames")
+	}
+
+	delays := make([]int, len(a.frames))
+	for index := range delays {
+		delays[index] = a.delay
+	}
+
+	return gif.EncodeAll(writer, &gif.GIF{Image: a.frames, Delay: delays})
+}
+
+func (a *Animation) Len() int {
+	return len(a.frames)
+}
+
+// Save writes the animation as a GIF, unless filename ends in .png, in which case only the last frame is written.
+func (a *Animation) Save(filename string) error {
+	if len(a.frames) == 0 {
+		return errors.New("animation has no frames")
+	}
+
+	if strings.EqualFold(filepath.Ext(filename), ".png") {
+		return SavePNG(filename, a.frames[len(a.frames)-1])
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := a.Encode(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+)
+
+// Each cell is drawn as a square of scale by scale pixels, with the top left of the region at the image's origin.
+
+var Monochrome = color.Palette{color.Black, color.White}
+
+// Draw paints every cell of region with whatever colour paint gives its coordinate.
+func Draw(region geometry.Rect, scale int, paint func(coordinate geometry.Coordinate) color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, region.Width()*scale, region.Height()*scale))
+
+	region.ForEach(func(coordinate geometry.Coordinate) {
+		cellColor := paint(coordinate)
+		origin := coordinate.Sub(region.Min).Scale(scale)
+		for y := 0; y < scale; y++ {
+			for x := 0; x < scale; x++ {
+				img.Set(origin.X+x, origin.Y+y, cellColor)
+			}
+		}
+	})
+
+	return img
+}
+
+func DrawGrid[T any](view collections.GridView[T], scale int, paint func(value T) color.Color) *image.RGBA {
+	region := geometry.RectFromSize(geometry.NewCoordinate(0, 0), view.Width(), view.Height())
+	return Draw(region, scale, func(coordinate geometry.Coordinate) color.Color {
+		return paint(view.Get(coordinate))
+	})
+}
+
+// DrawMatrix leaves out the border.
+func DrawMatrix(matrix *collections.BorderedIntMatrix, scale int, paint func(value int) color.Color) *image.RGBA {
+	return Draw(matrix.Bounds().Expand(-1), scale, func(coordinate geometry.Coordinate) color.Color {
+		return paint(matrix.ValueAt(coordinate.X, coordinate.Y))
+	})
+}
+
+// DrawPoints paints the points found in region with on and everything else with off.
+func DrawPoints(points []geometry.Coordinate, region geometry.Rect, scale int, on, off color.Color) *image.RGBA {
+	lit := make(map[geometry.Coordinate]bool)
+	for _, point := range points {
+		lit[point] = true
+	}
+
+	return Draw(region, scale, func(coordinate geometry.Coordinate) color.Color {
+		if lit[coordinate] {
+			return on
+		}
+		return off
+	})
+}
+
+// Gradient blends evenly from one colour to another; it needs at least two steps.
+func Gradient(from, to color.Color, steps int) color.Palette {
+	fromRGBA := color.RGBAModel.Convert(from).(color.RGBA)
+	toRGBA := color.RGBAModel.Convert(to).(color.RGBA)
+
+	blend := func(a, b uint8, step int) uint8 {
+		return uint8(int(a) + (int(b)-int(a))*step/(steps-1))
+	}
+
+	palette := make(color.Palette, steps)
+	for step := range palette {
+		palette[step] = color.RGBA{
+			R: blend(fromRGBA.R, toRGBA.R, step),
+			G: blend(fromRGBA.G, toRGBA.G, step),
+			B: blend(fromRGBA.B, toRGBA.B, step),
+			A: blend(fromRGBA.A, toRGBA.A, step),
+		}
+	}
+	return palette
+}
+
+func SavePNG(filename string, img image.Image) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
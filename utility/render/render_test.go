@@ -0,0 +1,130 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDrawGrid(t *testing.T) {
+	grid := collections.ParseDigitGrid([]string{"01", "10"})
+	img := DrawGrid[int](&grid, 3, func(value int) color.Color { return Monochrome[value] })
+
+	if img.Bounds().Dx() != 6 || img.Bounds().Dy() != 6 {
+		t.Logf("Expected a 6x6 image, got %v", img.Bounds())
+		t.FailNow()
+	}
+
+	for _, testCase := range []struct {
+		x, y     int
+		expected color.Color
+	}{{0, 0, color.Black}, {2, 2, color.Black}, {3, 0, color.White}, {5, 2, color.White}, {0, 3, color.White}, {4, 4, color.Black}} {
+		if !sameColor(img.At(testCase.x, testCase.y), testCase.expected) {
+			t.Logf("Expected %v at (%v, %v), got %v", testCase.expected, testCase.x, testCase.y, img.At(testCase.x, testCase.y))
+			t.Fail()
+		}
+	}
+}
+
+func TestDrawMatrix(t *testing.T) {
+	matrix := collections.NewBorderedIntMatrix()
+	matrix.Populate([]string{"123", "456"}, -1)
+
+	img := DrawMatrix(&matrix, 1, func(value int) color.Color { return color.Gray{Y: uint8(value * 10)} })
+	if img.Bounds().Dx() != 3 || img.Bounds().Dy() != 2 {
+		t.Logf("Expected the border to be left out, got %v", img.Bounds())
+		t.FailNow()
+	}
+
+	if !sameColor(img.At(0, 0), color.Gray{Y: 10}) || !sameColor(img.At(2, 1), color.Gray{Y: 60}) {
+		t.Logf("Expected the corners to be 1 and 6, got %v and %v", img.At(0, 0), img.At(2, 1))
+		t.Fail()
+	}
+}
+
+func TestDrawPoints(t *testing.T) {
+	region := geometry.NewRect(geometry.NewCoordinate(-2, -2), geometry.NewCoordinate(2, 2))
+	img := DrawPoints([]geometry.Coordinate{{X: -2, Y: -2}, {X: 0, Y: 0}, {X: 9, Y: 9}}, region, 1, color.White, color.Black)
+
+	if !sameColor(img.At(0, 0), color.White) || !sameColor(img.At(2, 2), color.White) || !sameColor(img.At(1, 1), color.Black) {
+		t.Log("Expected points to be drawn relative to the region's corner")
+		t.Fail()
+	}
+}
+
+func TestGradient(t *testing.T) {
+	palette := Gradient(color.Black, color.White, 3)
+	if !sameColor(palette[0], color.Black) || !sameColor(palette[2], color.White) || !sameColor(palette[1], color.RGBA{R: 127, G: 127, B: 127, A: 255}) {
+		t.Logf("Expected black, grey and white, got %v", palette)
+		t.Fail()
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	animation := NewAnimation(Monochrome, 10)
+	region := geometry.RectFromSize(geometry.NewCoordinate(0, 0), 4, 4)
+
+	for step := 0; step < 3; step++ {
+		frame := DrawPoints([]geometry.Coordinate{{X: step, Y: step}}, region, 2, color.White, color.Black)
+		if err := animation.AddFrame(frame); err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+	}
+
+	if err := animation.AddFrame(DrawPoints(nil, region, 1, color.White, color.Black)); err == nil {
+		t.Log("Expected a frame of a different size to be refused")
+		t.Fail()
+	}
+
+	var buffer bytes.Buffer
+	if err := animation.Encode(&buffer); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	decoded, err := gif.DecodeAll(&buffer)
+	if err != nil || len(decoded.Image) != 3 || decoded.Delay[0] != 10 {
+		t.Logf("Expected 3 frames shown for 10 hundredths each, got %v (%v)", len(decoded.Image), err)
+		t.FailNow()
+	}
+
+	if !sameColor(decoded.Image[2].At(4, 4), color.White) || !sameColor(decoded.Image[2].At(0, 0), color.Black) {
+		t.Log("Expected the last frame to have only its diagonal point lit")
+		t.Fail()
+	}
+}
+
+func TestAnimation_SavePNG(t *testing.T) {
+	animation := NewAnimation(Monochrome, 10)
+	region := geometry.RectFromSize(geometry.NewCoordinate(0, 0), 2, 1)
+	_ = animation.AddFrame(DrawPoints(nil, region, 1, color.White, color.Black))
+	_ = animation.AddFrame(DrawPoints([]geometry.Coordinate{{X: 1, Y: 0}}, region, 1, color.White, color.Black))
+
+	filename := filepath.Join(t.TempDir(), "last.png")
+	if err := animation.Save(filename); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	file, _ := os.Open(filename)
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil || !sameColor(img.At(1, 0), color.White) {
+		t.Logf("Expected the last frame to be saved, got %v", err)
+		t.Fail()
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
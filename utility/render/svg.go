@@ -0,0 +1,144 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SVG draws in the same cells as the images, scale pixels to a cell: rectangles cover whole cells, while lines,
+// polylines and text are placed at cell centres. Sizes in a Style are in cells too.
+// Elements are written in the order they were drawn with numbers always formatted the same way, so the same drawing
+// always produces the same text.
+
+type Style struct {
+	Fill        string
+	Stroke      string
+	StrokeWidth float64
+	Dashed      bool
+	FontSize    float64
+}
+
+type SVG struct {
+	region   geometry.Rect
+	scale    int
+	elements []string
+}
+
+func NewSVG(region geometry.Rect, scale int) SVG {
+	return SVG{
+		region: region,
+		scale:  scale,
+	}
+}
+
+func (s *SVG) Encode(writer io.Writer) error {
+	_, err := io.WriteString(writer, s.String())
+	return err
+}
+
+func (s *SVG) Len() int {
+	return len(s.elements)
+}
+
+func (s *SVG) Line(from, to geometry.Coordinate, style Style) {
+	s.add(fmt.Sprintf(`<line x1="%v" y1="%v" x2="%v" y2="%v"%v/>`,
+		centre(from.X), centre(from.Y), centre(to.X), centre(to.Y), style.attributes("none")))
+}
+
+func (s *SVG) Polyline(points []geometry.Coordinate, style Style) {
+	pairs := make([]string, len(points))
+	for index, point := range points {
+		pairs[index] = centre(point.X) + "," + centre(point.Y)
+	}
+	s.add(fmt.Sprintf(`<polyline points="%v"%v/>`, strings.Join(pairs, " "), style.attributes("none")))
+}
+
+func (s *SVG) Rect(rect geometry.Rect, style Style) {
+	if rect.IsEmpty() {
+		return
+	}
+	s.add(fmt.Sprintf(`<rect x="%v" y="%v" width="%v" height="%v"%v/>`,
+		rect.Min.X, rect.Min.Y, rect.Width(), rect.Height(), style.attributes("")))
+}
+
+func (s *SVG) Save(filename string) error {
+	return os.WriteFile(filename, []byte(s.String()), 0644)
+}
+
+func (s *SVG) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="%v %v %v %v">`+"\n",
+		s.region.Width()*s.scale, s.region.Height()*s.scale, s.region.Min.X, s.region.Min.Y, s.region.Width(), s.region.Height())
+	for _, element := range s.elements {
+		builder.WriteString("  ")
+		builder.WriteString(element)
+		builder.WriteString("\n")
+	}
+	builder.WriteString("</svg>\n")
+
+	return builder.String()
+}
+
+// Text is centred on the cell at; it is filled black unless the style says otherwise.
+func (s *SVG) Text(at geometry.Coordinate, text string, style Style) {
+	fontSize := style.FontSize
+	if fontSize == 0 {
+		fontSize = 0.8
+	}
+	if len(style.Fill) == 0 {
+		style.Fill = "#000000"
+	}
+	style.FontSize = 0
+
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+
+	s.add(fmt.Sprintf(`<text x="%v" y="%v" font-size="%v" text-anchor="middle" dominant-baseline="central"%v>%v</text>`,
+		centre(at.X), centre(at.Y), number(fontSize), style.attributes(""), escaped.String()))
+}
+
+func (s *SVG) add(element string) {
+	s.elements = append(s.elements, element)
+}
+
+// attributes falls back to defaultFill when the style has none, so that lines are not filled in.
+func (s Style) attributes(defaultFill string) string {
+	var builder strings.Builder
+
+	fill := s.Fill
+	if len(fill) == 0 {
+		fill = defaultFill
+	}
+	if len(fill) > 0 {
+		fmt.Fprintf(&builder, ` fill="%v"`, fill)
+	}
+	if len(s.Stroke) > 0 {
+		fmt.Fprintf(&builder, ` stroke="%v"`, s.Stroke)
+	}
+	if s.StrokeWidth > 0 {
+		fmt.Fprintf(&builder, ` stroke-width="%v"`, number(s.StrokeWidth))
+	}
+	if s.Dashed {
+		width := s.StrokeWidth
+		if width == 0 {
+			width = 0.1
+		}
+		fmt.Fprintf(&builder, ` stroke-dasharray="%v %v"`, number(width*4), number(width*2))
+	}
+
+	return builder.String()
+}
+
+func centre(cell int) string {
+	return number(float64(cell) + 0.5)
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/geometry"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	svg := NewSVG(geometry.RectFromSize(geometry.NewCoordinate(-1, 0), 4, 3), 10)
+	svg.Rect(geometry.NewRect(geometry.NewCoordinate(-1, 0), geometry.NewCoordinate(0, 0)), Style{Fill: "#cccccc"})
+	svg.Rect(geometry.EmptyRect, Style{Fill: "#ff0000"})
+	svg.Line(geometry.NewCoordinate(-1, 0), geometry.NewCoordinate(2, 2), Style{Stroke: "#000000", StrokeWidth: 0.25, Dashed: true})
+	svg.Polyline([]geometry.Coordinate{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, Style{Stroke: "#d62728", StrokeWidth: 0.2})
+	svg.Text(geometry.NewCoordinate(1, 2), "a<b & c", Style{})
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="40" height="30" viewBox="-1 0 4 3">
+  <rect x="-1" y="0" width="2" height="1" fill="#cccccc"/>
+  <line x1="-0.5" y1="0.5" x2="2.5" y2="2.5" fill="none" stroke="#000000" stroke-width="0.25" stroke-dasharray="1 0.5"/>
+  <polyline points="0.5,0.5 0.5,1.5 1.5,1.5" fill="none" stroke="#d62728" stroke-width="0.2"/>
+  <text x="1.5" y="2.5" font-size="0.8" text-anchor="middle" dominant-baseline="central" fill="#000000">a&lt;b &amp; c</text>
+</svg>
+`
+
+	if actual := svg.String(); actual != expected {
+		t.Logf("Expected\n%v\ngot\n%v", expected, actual)
+		t.Fail()
+	}
+
+	if svg.Len() != 4 {
+		t.Logf("Expected the empty rectangle to be left out, got %v elements", svg.Len())
+		t.Fail()
+	}
+}
+
+func TestSVG_Save(t *testing.T) {
+	svg := NewSVG(geometry.RectFromSize(geometry.NewCoordinate(0, 0), 2, 2), 1)
+	svg.Line(geometry.NewCoordinate(0, 0), geometry.NewCoordinate(1, 1), Style{Stroke: "#000000"})
+
+	filename := filepath.Join(t.TempDir(), "drawing.svg")
+	if err := svg.Save(filename); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	saved, _ := os.ReadFile(filename)
+	var encoded bytes.Buffer
+	_ = svg.Encode(&encoded)
+
+	if !bytes.Equal(saved, encoded.Bytes()) || string(saved) != svg.String() {
+		t.Log("Expected saving, encoding and String to agree")
+		t.Fail()
+	}
+}
@@ -0,0 +1,196 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"advent-of-code-2021/utility/geometry"
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Terminal redraws a grid in place with ANSI escape codes, one frame each time Show is called, so a simulation can be
+// watched as it runs. Cells are written two characters wide, as Print writes them, in the 256 colour palette entry
+// nearest whatever paint gives their value; cells that changed since the last frame are shown in reverse video.
+//
+// Once Controls is given something to read, commands are taken a line at a time:
+//
+//	p (or an empty line)  pause, or carry on
+//	s                     step one frame while paused
+//	q                     quit; Show returns false from then on
+type Terminal[T comparable] struct {
+	writer   io.Writer
+	interval time.Duration
+	paint    func(value T) color.Color
+	previous collections.Grid[T]
+	frames   int
+	commands chan string
+	echoes   bool
+	paused   bool
+	quit     bool
+}
+
+// NewTerminal shows framesPerSecond frames each second, or as many as it can when that is zero.
+func NewTerminal[T comparable](writer io.Writer, framesPerSecond float64, paint func(value T) color.Color) Terminal[T] {
+	var interval time.Duration
+	if framesPerSecond > 0 {
+		interval = time.Duration(float64(time.Second) / framesPerSecond)
+	}
+
+	return Terminal[T]{
+		writer:   writer,
+		interval: interval,
+		paint:    paint,
+	}
+}
+
+// ANSIColour finds the entry in the 6x6x6 colour cube of the 256 colour palette nearest to c.
+func ANSIColour(c color.Color) int {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	level := func(component uint8) int {
+		return (int(component)*5 + 127) / 255
+	}
+	return 16 + 36*level(rgba.R) + 6*level(rgba.G) + level(rgba.B)
+}
+
+// Controls reads commands from reader until it runs out, at which point a paused terminal carries on. When reader is
+// a terminal, each command is echoed on the line under the frame, and is cleared away again once it has been read.
+func (t *Terminal[T]) Controls(reader io.Reader) {
+	t.commands = make(chan string)
+	t.echoes = isTerminal(reader)
+
+	go func(commands chan<- string) {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			commands <- strings.TrimSpace(scanner.Text())
+		}
+		close(commands)
+	}(t.commands)
+}
+
+// Frames counts the frames shown so far.
+func (t *Terminal[T]) Frames() int {
+	return t.frames
+}
+
+// Pause holds the next frame on screen until it is stepped past or the terminal is told to carry on.
+func (t *Terminal[T]) Pause() {
+	t.paused = true
+}
+
+// Show draws a frame and waits until it is time for the next one, returning false if the viewer has quit.
+func (t *Terminal[T]) Show(view collections.GridView[T]) bool {
+	if t.quit {
+		return false
+	}
+
+	t.frames++
+	t.draw(view)
+
+	return t.wait()
+}
+
+func (t *Terminal[T]) draw(view collections.GridView[T]) {
+	var builder strings.Builder
+
+	redraw := t.frames > 1 && t.previous.Width() == view.Width() && t.previous.Height() == view.Height()
+	if redraw {
+		fmt.Fprintf(&builder, "\x1b[%vA\r", view.Height()+1)
+	}
+
+	for y := 0; y < view.Height(); y++ {
+		for x := 0; x < view.Width(); x++ {
+			coordinate := geometry.NewCoordinate(x, y)
+			value := view.Get(coordinate)
+
+			attributes := fmt.Sprintf("38;5;%v", ANSIColour(t.paint(value)))
+			if redraw && t.previous.Get(coordinate) != value {
+				attributes = "7;" + attributes
+			}
+			fmt.Fprintf(&builder, "\x1b[%vm%2v\x1b[0m ", attributes, value)
+		}
+		builder.WriteString("\x1b[K\n")
+	}
+
+	builder.WriteString(t.status())
+
+	_, _ = io.WriteString(t.writer, builder.String())
+	t.previous = collections.Materialize(view)
+}
+
+func (t *Terminal[T]) status() string {
+	state := "running"
+	if t.paused {
+		state = "paused"
+	}
+	return fmt.Sprintf("frame %v, %v\x1b[K\n", t.frames, state)
+}
+
+// wait holds the frame for the interval, or while paused; without any Controls there is nothing to unpause it, so it
+// never pauses.
+func (t *Terminal[T]) wait() bool {
+	if t.commands == nil {
+		t.paused = false
+	}
+
+	timer := time.NewTimer(t.interval)
+	defer timer.Stop()
+
+	for {
+		var tick <-chan time.Time
+		if !t.paused {
+			tick = timer.C
+		}
+
+		select {
+		case <-tick:
+			return true
+		case command, open := <-t.commands:
+			if !open {
+				t.commands = nil
+				t.paused = false
+				continue
+			}
+
+			stepped := false
+			switch command {
+			case "q":
+				t.quit = true
+			case "s":
+				stepped = t.paused
+			case "", "p":
+				t.paused = !t.paused
+			}
+			t.acknowledge()
+
+			if t.quit {
+				return false
+			}
+			if stepped {
+				return true
+			}
+		}
+	}
+}
+
+// acknowledge rewrites the status line, clearing everything below it, so the cursor is left where the next frame
+// expects it however many lines the command took up.
+func (t *Terminal[T]) acknowledge() {
+	lines := 1
+	if t.echoes {
+		lines++
+	}
+	_, _ = fmt.Fprintf(t.writer, "\x1b[%vA\r%v\x1b[J", lines, t.status())
+}
+
+func isTerminal(reader io.Reader) bool {
+	file, ok := reader.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"advent-of-code-2021/utility/collections"
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestANSIColour(t *testing.T) {
+	for _, testCase := range []struct {
+		colour   color.Color
+		expected int
+	}{
+		{color.Black, 16},
+		{color.White, 231},
+		{color.RGBA{R: 255, A: 255}, 196},
+		{color.RGBA{G: 160, B: 200, A: 255}, 38},
+	} {
+		if actual := ANSIColour(testCase.colour); actual != testCase.expected {
+			t.Logf("Expected %v to be %v, got %v", testCase.colour, testCase.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestTerminal_Show(t *testing.T) {
+	var output bytes.Buffer
+	terminal := NewTerminal[int](&output, 0, func(value int) color.Color { return Monochrome[value] })
+
+	grid := collections.ParseDigitGrid([]string{"01"})
+	terminal.Show(&grid)
+
+	expected := "\x1b[38;5;16m 0\x1b[0m \x1b[38;5;231m 1\x1b[0m \x1b[K\nframe 1, running\x1b[K\n"
+	if output.String() != expected {
+		t.Logf("Expected %q, got %q", expected, output.String())
+		t.Fail()
+	}
+
+	output.Reset()
+	grid.Set(grid.Coordinates()[0], 1)
+	terminal.Show(&grid)
+
+	expected = "\x1b[2A\r\x1b[7;38;5;231m 1\x1b[0m \x1b[38;5;231m 1\x1b[0m \x1b[K\nframe 2, running\x1b[K\n"
+	if output.String() != expected {
+		t.Logf("Expected the first cell to be highlighted in %q, got %q", expected, output.String())
+		t.Fail()
+	}
+}
+
+func TestTerminal_ShowClearsEchoedCommands(t *testing.T) {
+	var output bytes.Buffer
+	terminal := NewTerminal[int](&output, 0.001, func(int) color.Color { return color.White })
+	terminal.Pause()
+	terminal.Controls(strings.NewReader("p\np\ns\nq\n"))
+	terminal.echoes = true
+
+	grid := collections.ParseDigitGrid([]string{"0"})
+	done := make(chan bool)
+	go func() { done <- terminal.Show(&grid) && !terminal.Show(&grid) }()
+
+	select {
+	case shown := <-done:
+		if !shown {
+			t.Log("Expected to step once and then quit")
+			t.FailNow()
+		}
+	case <-time.After(time.Second):
+		t.Log("Expected the commands to step and then quit")
+		t.FailNow()
+	}
+
+	expected := "\x1b[38;5;231m 0\x1b[0m \x1b[K\nframe 1, paused\x1b[K\n" +
+		"\x1b[2A\rframe 1, running\x1b[K\n\x1b[J" +
+		"\x1b[2A\rframe 1, paused\x1b[K\n\x1b[J" +
+		"\x1b[2A\rframe 1, paused\x1b[K\n\x1b[J" +
+		"\x1b[2A\r\x1b[38;5;231m 0\x1b[0m \x1b[K\nframe 2, paused\x1b[K\n" +
+		"\x1b[2A\rframe 2, paused\x1b[K\n\x1b[J"
+	if output.String() != expected {
+		t.Logf("Expected each echoed command to be cleared from under the frame in %q, got %q", expected, output.String())
+		t.Fail()
+	}
+}
+
+func TestTerminal_Controls(t *testing.T) {
+	terminal := NewTerminal[int](&bytes.Buffer{}, 0.001, func(int) color.Color { return color.White })
+	terminal.Pause()
+	terminal.Controls(strings.NewReader("s\ns\nq\n"))
+
+	grid := collections.ParseDigitGrid([]string{"0"})
+	done := make(chan []bool)
+	go func() {
+		done <- []bool{terminal.Show(&grid), terminal.Show(&grid), terminal.Show(&grid), terminal.Show(&grid)}
+	}()
+
+	select {
+	case shown := <-done:
+		if !shown[0] || !shown[1] || shown[2] || shown[3] || terminal.Frames() != 3 {
+			t.Logf("Expected two steps and then to quit, got %v after %v frames", shown, terminal.Frames())
+			t.Fail()
+		}
+	case <-time.After(time.Second):
+		t.Log("Expected stepping to move on from a paused frame without waiting")
+		t.Fail()
+	}
+}
+
+func TestTerminal_CarriesOnWhenControlsRunOut(t *testing.T) {
+	terminal := NewTerminal[int](&bytes.Buffer{}, 1000, func(int) color.Color { return color.White })
+	terminal.Pause()
+	terminal.Controls(strings.NewReader(""))
+
+	grid := collections.ParseDigitGrid([]string{"0"})
+	done := make(chan bool)
+	go func() { done <- terminal.Show(&grid) }()
+
+	select {
+	case shown := <-done:
+		if !shown {
+			t.Log("Expected the terminal to carry on")
+			t.Fail()
+		}
+	case <-time.After(time.Second):
+		t.Log("Expected a paused terminal to carry on once there are no more commands")
+		t.Fail()
+	}
+}